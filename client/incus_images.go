@@ -1021,6 +1021,21 @@ func (r *ProtocolIncus) RefreshImage(fingerprint string) (Operation, error) {
 	return op, nil
 }
 
+// CacheImage requests that Incus creates the image's volume in the given storage pool ahead of time.
+func (r *ProtocolIncus) CacheImage(fingerprint string, pool string) (Operation, error) {
+	if !r.HasExtension("image_cache") {
+		return nil, errors.New("The server is missing the required \"image_cache\" API extension")
+	}
+
+	// Send the request
+	op, _, err := r.queryOperation("POST", fmt.Sprintf("/images/%s/cache", url.PathEscape(fingerprint)), api.ImageCachePost{Pool: pool}, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
 // CreateImageSecret requests that Incus issues a temporary image secret.
 func (r *ProtocolIncus) CreateImageSecret(fingerprint string) (Operation, error) {
 	// Send the request
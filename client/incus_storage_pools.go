@@ -143,3 +143,33 @@ func (r *ProtocolIncus) GetStoragePoolResources(name string) (*api.ResourcesStor
 
 	return &res, nil
 }
+
+// AuditStoragePool looks for inconsistencies on the storage pool, the report is in the operation metadata.
+func (r *ProtocolIncus) AuditStoragePool(name string) (Operation, error) {
+	if !r.HasExtension("storage_pool_audit") {
+		return nil, errors.New("The server is missing the required \"storage_pool_audit\" API extension")
+	}
+
+	// Send the request
+	op, _, err := r.queryOperation("POST", fmt.Sprintf("/storage-pools/%s/audit", url.PathEscape(name)), nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
+// ReconcileStoragePool repairs the storage metadata of the pool's volumes, the changes are in the operation metadata.
+func (r *ProtocolIncus) ReconcileStoragePool(name string) (Operation, error) {
+	if !r.HasExtension("storage_pool_reconcile") {
+		return nil, errors.New("The server is missing the required \"storage_pool_reconcile\" API extension")
+	}
+
+	// Send the request
+	op, _, err := r.queryOperation("POST", fmt.Sprintf("/storage-pools/%s/reconcile", url.PathEscape(name)), nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
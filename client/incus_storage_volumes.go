@@ -994,6 +994,78 @@ func (r *ProtocolIncus) GetStorageVolumeBackupFile(pool string, volName string,
 	return &resp, nil
 }
 
+// GetStorageVolumeDiskFile requests the custom or virtual machine block volume (or the given snapshot of it) as a qcow2 disk image.
+func (r *ProtocolIncus) GetStorageVolumeDiskFile(pool string, volType string, volName string, snapshotName string, req *BackupFileRequest) (*BackupFileResponse, error) {
+	if !r.HasExtension("storage_volume_disk_export") {
+		return nil, errors.New("The server is missing the required \"storage_volume_disk_export\" API extension")
+	}
+
+	// Build the URL
+	values := url.Values{}
+	values.Set("format", "qcow2")
+	if snapshotName != "" {
+		values.Set("snapshot", snapshotName)
+	}
+
+	uri := fmt.Sprintf("%s/1.0/storage-pools/%s/volumes/%s/%s/disk?%s", r.httpBaseURL.String(), url.PathEscape(pool), url.PathEscape(volType), url.PathEscape(volName), values.Encode())
+
+	// Add project/target
+	uri, err := r.setQueryAttributes(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prepare the download request
+	request, err := http.NewRequest("GET", uri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.httpUserAgent != "" {
+		request.Header.Set("User-Agent", r.httpUserAgent)
+	}
+
+	// Start the request
+	response, doneCh, err := cancel.CancelableDownload(req.Canceler, r.DoHTTP, request)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = response.Body.Close() }()
+	defer close(doneCh)
+
+	if response.StatusCode != http.StatusOK {
+		_, _, err := incusParseResponse(response)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Handle the data
+	body := response.Body
+	if req.ProgressHandler != nil {
+		body = &ioprogress.ProgressReader{
+			ReadCloser: response.Body,
+			Tracker: &ioprogress.ProgressTracker{
+				Length: response.ContentLength,
+				Handler: func(percent int64, speed int64) {
+					req.ProgressHandler(ioprogress.ProgressData{Text: fmt.Sprintf("%d%% (%s/s)", percent, units.GetByteSizeString(speed, 2))})
+				},
+			},
+		}
+	}
+
+	size, err := io.Copy(req.BackupFile, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := BackupFileResponse{}
+	resp.Size = size
+
+	return &resp, nil
+}
+
 // CreateStoragePoolVolumeFromMigration defines a new storage volume.
 // In contrast to CreateStoragePoolVolume, it also returns an operation object.
 func (r *ProtocolIncus) CreateStoragePoolVolumeFromMigration(pool string, volume api.StorageVolumesPost) (Operation, error) {
@@ -214,6 +214,63 @@ var DevIncusDevicesGet = devIncusHandler{"/1.0/devices", func(d *Daemon, w http.
 	return okResponse(devices, "json")
 }}
 
+var DevIncusNetworkConfigGet = devIncusHandler{"/1.0/network-config", func(d *Daemon, w http.ResponseWriter, r *http.Request) *devIncusResponse {
+	client, err := getVsockClient(d)
+	if err != nil {
+		return smartResponse(fmt.Errorf("Failed connecting to host over vsock: %w", err))
+	}
+
+	defer client.Disconnect()
+
+	resp, _, err := client.RawQuery("GET", "/1.0/network-config", nil, "")
+	if err != nil {
+		return smartResponse(err)
+	}
+
+	var networkConfig string
+
+	err = resp.MetadataAsStruct(&networkConfig)
+	if err != nil {
+		return smartResponse(fmt.Errorf("Failed parsing response from host: %w", err))
+	}
+
+	return okResponse(networkConfig, "raw")
+}}
+
+var DevIncusCloudInitStatus = devIncusHandler{"/1.0/cloud-init/status", func(d *Daemon, w http.ResponseWriter, r *http.Request) *devIncusResponse {
+	client, err := getVsockClient(d)
+	if err != nil {
+		return smartResponse(fmt.Errorf("Failed connecting to host over vsock: %w", err))
+	}
+
+	defer client.Disconnect()
+
+	if r.Method == "GET" {
+		resp, _, err := client.RawQuery(r.Method, "/1.0/cloud-init/status", nil, "")
+		if err != nil {
+			return smartResponse(err)
+		}
+
+		var status api.DevIncusCloudInitStatus
+
+		err = resp.MetadataAsStruct(&status)
+		if err != nil {
+			return smartResponse(fmt.Errorf("Failed parsing response from host: %w", err))
+		}
+
+		return okResponse(status, "json")
+	} else if r.Method == "PATCH" {
+		_, _, err := client.RawQuery(r.Method, "/1.0/cloud-init/status", r.Body, "")
+		if err != nil {
+			return smartResponse(err)
+		}
+
+		return okResponse("", "raw")
+	}
+
+	return &devIncusResponse{fmt.Sprintf("method %q not allowed", r.Method), http.StatusBadRequest, "raw"}
+}}
+
 var handlers = []devIncusHandler{
 	{"/", func(d *Daemon, w http.ResponseWriter, r *http.Request) *devIncusResponse {
 		return okResponse([]string{"/1.0"}, "json")
@@ -222,8 +279,10 @@ var handlers = []devIncusHandler{
 	DevIncusConfigGet,
 	DevIncusConfigKeyGet,
 	DevIncusMetadataGet,
+	DevIncusNetworkConfigGet,
 	devIncusEventsGet,
 	DevIncusDevicesGet,
+	DevIncusCloudInitStatus,
 }
 
 func hoistReq(f func(*Daemon, http.ResponseWriter, *http.Request) *devIncusResponse, d *Daemon) func(http.ResponseWriter, *http.Request) {
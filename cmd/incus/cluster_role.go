@@ -1,11 +1,14 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"slices"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
 
 	cli "github.com/lxc/incus/v6/internal/cmd"
 	"github.com/lxc/incus/v6/internal/i18n"
@@ -38,10 +41,48 @@ func (c *cmdClusterRole) Command() *cobra.Command {
 	return cmd
 }
 
+// validateFormat checks the --format flag of the add and remove commands.
+func (c *cmdClusterRole) validateFormat(format string) error {
+	if format != "" && !slices.Contains([]string{"json", "yaml"}, format) {
+		return fmt.Errorf(i18n.G("Invalid format: %s"), format)
+	}
+
+	return nil
+}
+
+// renderRoles prints the roles of a member in the requested format (nothing when no format is requested).
+func (c *cmdClusterRole) renderRoles(format string, roles []string) error {
+	if roles == nil {
+		roles = []string{}
+	}
+
+	var render []byte
+	var err error
+
+	switch format {
+	case "json":
+		render, err = json.Marshal(roles)
+	case "yaml":
+		render, err = yaml.Marshal(roles)
+	default:
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(strings.TrimSpace(string(render)))
+	return nil
+}
+
 type cmdClusterRoleAdd struct {
 	global      *cmdGlobal
 	cluster     *cmdCluster
 	clusterRole *cmdClusterRole
+
+	flagFormat         string
+	flagIgnoreExisting bool
 }
 
 // Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
@@ -54,6 +95,8 @@ func (c *cmdClusterRoleAdd) Command() *cobra.Command {
 		`Add roles to a cluster member`))
 
 	cmd.RunE = c.Run
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "", i18n.G("Print the resulting roles of the member (json|yaml)")+"``")
+	cmd.Flags().BoolVar(&c.flagIgnoreExisting, "ignore-existing", false, i18n.G("Don't fail if the member already has a role"))
 
 	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		if len(args) == 0 {
@@ -73,6 +116,11 @@ func (c *cmdClusterRoleAdd) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	err = c.clusterRole.validateFormat(c.flagFormat)
+	if err != nil {
+		return err
+	}
+
 	resources, err := c.global.parseServers(args[0])
 	if err != nil {
 		return err
@@ -91,22 +139,38 @@ func (c *cmdClusterRoleAdd) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	memberWritable := member.Writable()
-	newRoles := util.SplitNTrimSpace(args[1], ",", -1, false)
-	for _, newRole := range newRoles {
+	newRoles := []string{}
+	for _, newRole := range util.SplitNTrimSpace(args[1], ",", -1, false) {
 		if slices.Contains(memberWritable.Roles, newRole) {
+			if c.flagIgnoreExisting {
+				continue
+			}
+
 			return fmt.Errorf(i18n.G("Member %q already has role %q"), resource.name, newRole)
 		}
+
+		newRoles = append(newRoles, newRole)
 	}
 
-	memberWritable.Roles = append(memberWritable.Roles, newRoles...)
+	if len(newRoles) > 0 {
+		memberWritable.Roles = append(memberWritable.Roles, newRoles...)
 
-	return resource.server.UpdateClusterMember(resource.name, memberWritable, etag)
+		err = resource.server.UpdateClusterMember(resource.name, memberWritable, etag)
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.clusterRole.renderRoles(c.flagFormat, memberWritable.Roles)
 }
 
 type cmdClusterRoleRemove struct {
 	global      *cmdGlobal
 	cluster     *cmdCluster
 	clusterRole *cmdClusterRole
+
+	flagFormat        string
+	flagIgnoreMissing bool
 }
 
 // Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
@@ -119,6 +183,8 @@ func (c *cmdClusterRoleRemove) Command() *cobra.Command {
 		`Remove roles from a cluster member`))
 
 	cmd.RunE = c.Run
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "", i18n.G("Print the resulting roles of the member (json|yaml)")+"``")
+	cmd.Flags().BoolVar(&c.flagIgnoreMissing, "ignore-missing", false, i18n.G("Don't fail if the member doesn't have a role"))
 
 	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		if len(args) == 0 {
@@ -142,6 +208,11 @@ func (c *cmdClusterRoleRemove) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	err = c.clusterRole.validateFormat(c.flagFormat)
+	if err != nil {
+		return err
+	}
+
 	resources, err := c.global.parseServers(args[0])
 	if err != nil {
 		return err
@@ -160,14 +231,27 @@ func (c *cmdClusterRoleRemove) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	memberWritable := member.Writable()
-	rolesToRemove := util.SplitNTrimSpace(args[1], ",", -1, false)
-	for _, roleToRemove := range rolesToRemove {
+	rolesToRemove := []string{}
+	for _, roleToRemove := range util.SplitNTrimSpace(args[1], ",", -1, false) {
 		if !slices.Contains(memberWritable.Roles, roleToRemove) {
+			if c.flagIgnoreMissing {
+				continue
+			}
+
 			return fmt.Errorf(i18n.G("Member %q does not have role %q"), resource.name, roleToRemove)
 		}
+
+		rolesToRemove = append(rolesToRemove, roleToRemove)
 	}
 
-	memberWritable.Roles = removeElementsFromSlice(memberWritable.Roles, rolesToRemove...)
+	if len(rolesToRemove) > 0 {
+		memberWritable.Roles = removeElementsFromSlice(memberWritable.Roles, rolesToRemove...)
+
+		err = resource.server.UpdateClusterMember(resource.name, memberWritable, etag)
+		if err != nil {
+			return err
+		}
+	}
 
-	return resource.server.UpdateClusterMember(resource.name, memberWritable, etag)
+	return c.clusterRole.renderRoles(c.flagFormat, memberWritable.Roles)
 }
@@ -94,6 +94,10 @@ hash or alias name (if one is set).`))
 	imageRefreshCmd := cmdImageRefresh{global: c.global, image: c}
 	cmd.AddCommand(imageRefreshCmd.Command())
 
+	// Cache
+	imageCacheCmd := cmdImageCache{global: c.global, image: c}
+	cmd.AddCommand(imageCacheCmd.Command())
+
 	// Show
 	imageShowCmd := cmdImageShow{global: c.global, image: c}
 	cmd.AddCommand(imageShowCmd.Command())
@@ -1514,6 +1518,98 @@ func (c *cmdImageRefresh) Run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// Cache.
+type cmdImageCache struct {
+	global *cmdGlobal
+	image  *cmdImage
+
+	flagTarget string
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdImageCache) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("cache", i18n.G("[<remote>:]<image> <pool>"))
+	cmd.Short = i18n.G("Cache images in storage pools")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Cache images in storage pools
+
+Prepares the image's volume in the storage pool ahead of time so that
+creating instances from the image in that pool is fast.`))
+	cmd.Example = cli.FormatSection("", i18n.G(`incus image cache debian/12 local
+    Cache the debian/12 image in the "local" storage pool`))
+
+	cmd.Flags().StringVar(&c.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpImages(toComplete)
+		}
+
+		if len(args) == 1 {
+			return c.global.cmpStoragePools(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdImageCache) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Image identifier missing"))
+	}
+
+	client := resource.server
+	if c.flagTarget != "" {
+		client = client.UseTarget(c.flagTarget)
+	}
+
+	image := c.image.dereferenceAlias(client, "", resource.name)
+	progress := cli.ProgressRenderer{
+		Format: i18n.G("Caching the image: %s"),
+		Quiet:  c.global.flagQuiet,
+	}
+
+	op, err := client.CacheImage(image, args[1])
+	if err != nil {
+		return err
+	}
+
+	// Register progress handler
+	_, err = op.AddHandler(progress.UpdateOp)
+	if err != nil {
+		return err
+	}
+
+	err = op.Wait()
+	if err != nil {
+		progress.Done("")
+		return err
+	}
+
+	progress.Done(i18n.G("Image cached successfully!"))
+
+	return nil
+}
+
 // Show.
 type cmdImageShow struct {
 	global *cmdGlobal
@@ -1,29 +1,151 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"maps"
+	"net/http"
 	"os"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v2"
 
+	incus "github.com/lxc/incus/v6/client"
 	cli "github.com/lxc/incus/v6/internal/cmd"
 	"github.com/lxc/incus/v6/internal/i18n"
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/termios"
 	"github.com/lxc/incus/v6/shared/util"
+	"github.com/lxc/incus/v6/shared/validate"
 )
 
+// networkLoadBalancerConfigRules are the load balancer configuration keys validated before sending to the server.
+var networkLoadBalancerConfigRules = map[string]func(value string) error{
+	"healthcheck":               validate.Optional(validate.IsBool),
+	"healthcheck.interval":      validate.Optional(validate.IsUint32),
+	"healthcheck.success_count": validate.Optional(validate.IsUint32),
+	"healthcheck.failure_count": validate.Optional(validate.IsUint32),
+	"healthcheck.timeout":       validate.Optional(validate.IsUint32),
+}
+
+// validateNetworkLoadBalancerConfig checks the known load balancer configuration keys, other keys are left to the server.
+func validateNetworkLoadBalancerConfig(config map[string]string) error {
+	for k, v := range config {
+		validator, ok := networkLoadBalancerConfigRules[k]
+		if !ok {
+			continue
+		}
+
+		err := validator(v)
+		if err != nil {
+			return fmt.Errorf(i18n.G("Invalid value for load balancer key %q: %w"), k, err)
+		}
+	}
+
+	return nil
+}
+
 type cmdNetworkLoadBalancer struct {
 	global     *cmdGlobal
 	flagTarget string
 }
 
+// networkLoadBalancerUpdateAttempts is how many times a load balancer change is attempted when the load
+// balancer keeps being modified concurrently.
+const networkLoadBalancerUpdateAttempts = 5
+
+// updateLoadBalancer fetches a load balancer, applies the change to it and submits it with the fetched ETag.
+// If the load balancer was modified in the meantime, the change is applied again to the fresh load balancer.
+func (c *cmdNetworkLoadBalancer) updateLoadBalancer(client incus.InstanceServer, networkName string, listenAddress string, change func(loadBalancer *api.NetworkLoadBalancer) error) error {
+	var err error
+
+	for range networkLoadBalancerUpdateAttempts {
+		var loadBalancer *api.NetworkLoadBalancer
+		var etag string
+
+		// Get the network load balancer.
+		loadBalancer, etag, err = client.GetNetworkLoadBalancer(networkName, listenAddress)
+		if err != nil {
+			return err
+		}
+
+		err = change(loadBalancer)
+		if err != nil {
+			return err
+		}
+
+		loadBalancer.Normalise()
+
+		err = client.UpdateNetworkLoadBalancer(networkName, loadBalancer.ListenAddress, loadBalancer.Writable(), etag)
+		if !api.StatusErrorCheck(err, http.StatusPreconditionFailed) {
+			return err
+		}
+	}
+
+	return fmt.Errorf(i18n.G("Load balancer kept being modified concurrently, giving up after %d attempts: %w"), networkLoadBalancerUpdateAttempts, err)
+}
+
+// networkLoadBalancerPortCount returns the number of ports in a comma separated list of ports and port ranges.
+func networkLoadBalancerPortCount(ports string) (int, error) {
+	count := 0
+	for _, portRange := range util.SplitNTrimSpace(ports, ",", -1, true) {
+		err := validate.IsNetworkPortRange(portRange)
+		if err != nil {
+			return -1, err
+		}
+
+		start, end, found := strings.Cut(portRange, "-")
+		if !found {
+			count++
+			continue
+		}
+
+		startPort, _ := strconv.Atoi(start)
+		endPort, _ := strconv.Atoi(end)
+		count += endPort - startPort + 1
+	}
+
+	return count, nil
+}
+
+// checkBackendPorts checks that the target ports of the load balancer's backends are compatible with the
+// listen ports of the port specifications referencing them.
+func (c *cmdNetworkLoadBalancer) checkBackendPorts(loadBalancer *api.NetworkLoadBalancer) error {
+	targetPortCounts := make(map[string]int, len(loadBalancer.Backends))
+	for _, backend := range loadBalancer.Backends {
+		count, err := networkLoadBalancerPortCount(backend.TargetPort)
+		if err != nil {
+			return fmt.Errorf(i18n.G("Invalid target port(s) for backend %q: %w"), backend.Name, err)
+		}
+
+		targetPortCounts[backend.Name] = count
+	}
+
+	for _, port := range loadBalancer.Ports {
+		listenPortCount, err := networkLoadBalancerPortCount(port.ListenPort)
+		if err != nil {
+			return fmt.Errorf(i18n.G("Invalid listen port(s) %q: %w"), port.ListenPort, err)
+		}
+
+		for _, backendName := range port.TargetBackend {
+			// A single target port maps all listen ports onto it, no target port maps them one-to-one.
+			targetPortCount := targetPortCounts[backendName]
+			if targetPortCount > 1 && targetPortCount != listenPortCount {
+				return fmt.Errorf(i18n.G("Backend %q has %d target port(s) but is used by listen port(s) %q (%d port(s))"), backendName, targetPortCount, port.ListenPort, listenPortCount)
+			}
+		}
+	}
+
+	return nil
+}
+
 // Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
 func (c *cmdNetworkLoadBalancer) Command() *cobra.Command {
 	cmd := &cobra.Command{}
@@ -51,6 +173,10 @@ func (c *cmdNetworkLoadBalancer) Command() *cobra.Command {
 	networkLoadBalancerInfoCmd := cmdNetworkLoadBalancerInfo{global: c.global, networkLoadBalancer: c}
 	cmd.AddCommand(networkLoadBalancerInfoCmd.Command())
 
+	// Health.
+	networkLoadBalancerHealthCmd := cmdNetworkLoadBalancerHealth{global: c.global, networkLoadBalancer: c}
+	cmd.AddCommand(networkLoadBalancerHealthCmd.Command())
+
 	// Set.
 	networkLoadBalancerSetCmd := cmdNetworkLoadBalancerSet{global: c.global, networkLoadBalancer: c}
 	cmd.AddCommand(networkLoadBalancerSetCmd.Command())
@@ -86,25 +212,35 @@ type cmdNetworkLoadBalancerList struct {
 	global              *cmdGlobal
 	networkLoadBalancer *cmdNetworkLoadBalancer
 
-	flagFormat  string
-	flagColumns string
+	flagFormat      string
+	flagColumns     string
+	flagAllNetworks bool
 }
 
 type networkLoadBalancerColumn struct {
 	Name string
-	Data func(api.NetworkLoadBalancer) string
+	Data func(networkLoadBalancerListEntry) string
+}
+
+// networkLoadBalancerListEntry is a load balancer along with the name of the network it belongs to.
+type networkLoadBalancerListEntry struct {
+	api.NetworkLoadBalancer `yaml:",inline"`
+
+	Network string `json:"network" yaml:"network"`
 }
 
 // Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
 func (c *cmdNetworkLoadBalancerList) Command() *cobra.Command {
 	cmd := &cobra.Command{}
-	cmd.Use = usage("list", i18n.G("[<remote>:]<network>"))
+	cmd.Use = usage("list", i18n.G("[<remote>:][<network>]"))
 	cmd.Aliases = []string{"ls"}
 	cmd.Short = i18n.G("List available network load balancers")
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
 		`List available network load balancers
 
-Default column layout: ldp
+Use --all-networks to list the load balancers of all networks.
+
+Default column layout: ldp (nldp with --all-networks)
 
 == Columns ==
 The -c option takes a comma separated list of arguments that control
@@ -117,6 +253,7 @@ or (extended) config keys.
 Commas between consecutive shorthand chars are optional.
 
 Pre-defined column shorthand chars:
+  n - Network
   l - Listen Address
   d - Description
   p - Ports
@@ -125,6 +262,7 @@ Pre-defined column shorthand chars:
 	cmd.RunE = c.Run
 	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", c.global.defaultListFormat(), i18n.G(`Format (csv|json|table|yaml|compact|markdown), use suffix ",noheader" to disable headers and ",header" to enable it if missing, e.g. csv,header`)+"``")
 	cmd.Flags().StringVarP(&c.flagColumns, "columns", "c", defaultNetworkLoadBalancerColumns, i18n.G("Columns")+"``")
+	cmd.Flags().BoolVar(&c.flagAllNetworks, "all-networks", false, i18n.G("List load balancers of all networks"))
 
 	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
 		return cli.ValidateFlagFormatForListOutput(cmd.Flag("format").Value.String())
@@ -145,6 +283,7 @@ const defaultNetworkLoadBalancerColumns = "ldp"
 
 func (c *cmdNetworkLoadBalancerList) parseColumns(clustered bool) ([]networkLoadBalancerColumn, error) {
 	columnsShorthandMap := map[rune]networkLoadBalancerColumn{
+		'n': {i18n.G("NETWORK"), c.networkColumnData},
 		'l': {i18n.G("LISTEN ADDRESS"), c.listenAddressColumnData},
 		'd': {i18n.G("DESCRIPTION"), c.descriptionColumnData},
 		'p': {i18n.G("PORTS"), c.portsColumnData},
@@ -153,8 +292,14 @@ func (c *cmdNetworkLoadBalancerList) parseColumns(clustered bool) ([]networkLoad
 
 	columnList := strings.Split(c.flagColumns, ",")
 	columns := []networkLoadBalancerColumn{}
-	if c.flagColumns == defaultNetworkLoadBalancerColumns && clustered {
-		columnList = append(columnList, "L")
+	if c.flagColumns == defaultNetworkLoadBalancerColumns {
+		if c.flagAllNetworks {
+			columnList = append([]string{"n"}, columnList...)
+		}
+
+		if clustered {
+			columnList = append(columnList, "L")
+		}
 	}
 
 	for _, columnEntry := range columnList {
@@ -175,26 +320,30 @@ func (c *cmdNetworkLoadBalancerList) parseColumns(clustered bool) ([]networkLoad
 	return columns, nil
 }
 
-func (c *cmdNetworkLoadBalancerList) listenAddressColumnData(loadBalancer api.NetworkLoadBalancer) string {
+func (c *cmdNetworkLoadBalancerList) networkColumnData(loadBalancer networkLoadBalancerListEntry) string {
+	return loadBalancer.Network
+}
+
+func (c *cmdNetworkLoadBalancerList) listenAddressColumnData(loadBalancer networkLoadBalancerListEntry) string {
 	return loadBalancer.ListenAddress
 }
 
-func (c *cmdNetworkLoadBalancerList) descriptionColumnData(loadBalancer api.NetworkLoadBalancer) string {
+func (c *cmdNetworkLoadBalancerList) descriptionColumnData(loadBalancer networkLoadBalancerListEntry) string {
 	return loadBalancer.Description
 }
 
-func (c *cmdNetworkLoadBalancerList) portsColumnData(loadBalancer api.NetworkLoadBalancer) string {
+func (c *cmdNetworkLoadBalancerList) portsColumnData(loadBalancer networkLoadBalancerListEntry) string {
 	return fmt.Sprintf("%d", len(loadBalancer.Ports))
 }
 
-func (c *cmdNetworkLoadBalancerList) locationColumnData(loadBalancer api.NetworkLoadBalancer) string {
+func (c *cmdNetworkLoadBalancerList) locationColumnData(loadBalancer networkLoadBalancerListEntry) string {
 	return loadBalancer.Location
 }
 
 // Run runs the actual command logic.
 func (c *cmdNetworkLoadBalancerList) Run(cmd *cobra.Command, args []string) error {
 	// Quick checks.
-	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	exit, err := c.global.checkArgs(cmd, args, 0, 1)
 	if exit {
 		return err
 	}
@@ -212,13 +361,40 @@ func (c *cmdNetworkLoadBalancerList) Run(cmd *cobra.Command, args []string) erro
 
 	resource := resources[0]
 
-	if resource.name == "" {
+	if c.flagAllNetworks && resource.name != "" {
+		return errors.New(i18n.G("A network name can't be used with --all-networks"))
+	}
+
+	if !c.flagAllNetworks && resource.name == "" {
 		return errors.New(i18n.G("Missing network name"))
 	}
 
-	loadBalancers, err := resource.server.GetNetworkLoadBalancers(resource.name)
-	if err != nil {
-		return err
+	networkNames := []string{resource.name}
+	if c.flagAllNetworks {
+		networks, err := resource.server.GetNetworks()
+		if err != nil {
+			return err
+		}
+
+		// Only OVN networks support load balancers.
+		networkNames = []string{}
+		for _, network := range networks {
+			if network.Managed && network.Type == "ovn" {
+				networkNames = append(networkNames, network.Name)
+			}
+		}
+	}
+
+	loadBalancers := []networkLoadBalancerListEntry{}
+	for _, networkName := range networkNames {
+		networkLoadBalancers, err := resource.server.GetNetworkLoadBalancers(networkName)
+		if err != nil {
+			return err
+		}
+
+		for _, loadBalancer := range networkLoadBalancers {
+			loadBalancers = append(loadBalancers, networkLoadBalancerListEntry{NetworkLoadBalancer: loadBalancer, Network: networkName})
+		}
 	}
 
 	// Parse column flags.
@@ -245,6 +421,16 @@ func (c *cmdNetworkLoadBalancerList) Run(cmd *cobra.Command, args []string) erro
 		header = append(header, column.Name)
 	}
 
+	// Keep the raw output of a single network unchanged.
+	if !c.flagAllNetworks {
+		rawLoadBalancers := make([]api.NetworkLoadBalancer, 0, len(loadBalancers))
+		for _, loadBalancer := range loadBalancers {
+			rawLoadBalancers = append(rawLoadBalancers, loadBalancer.NetworkLoadBalancer)
+		}
+
+		return cli.RenderTable(os.Stdout, c.flagFormat, header, data, rawLoadBalancers)
+	}
+
 	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, loadBalancers)
 }
 
@@ -331,24 +517,88 @@ type cmdNetworkLoadBalancerCreate struct {
 	global              *cmdGlobal
 	networkLoadBalancer *cmdNetworkLoadBalancer
 	flagDescription     string
+	flagFrom            string
+	flagTemplate        string
+}
+
+// networkLoadBalancerDefinitionMaxSize is the maximum size of a load balancer definition read with --from.
+const networkLoadBalancerDefinitionMaxSize = 1024 * 1024
+
+// networkLoadBalancerDefinitionTimeout is the timeout for fetching a load balancer definition from a URL.
+const networkLoadBalancerDefinitionTimeout = 30 * time.Second
+
+// readDefinition returns the content of the load balancer definition at the given file path or http(s) URL.
+func (c *cmdNetworkLoadBalancerCreate) readDefinition(source string) ([]byte, error) {
+	var reader io.Reader
+
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		ctx, cancel := context.WithTimeout(context.Background(), networkLoadBalancerDefinitionTimeout)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf(i18n.G("Failed fetching load balancer definition from %q: %w"), source, err)
+		}
+
+		defer func() { _ = resp.Body.Close() }()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf(i18n.G("Failed fetching load balancer definition from %q: %s"), source, resp.Status)
+		}
+
+		reader = resp.Body
+	} else {
+		f, err := os.Open(source)
+		if err != nil {
+			return nil, err
+		}
+
+		defer func() { _ = f.Close() }()
+
+		reader = f
+	}
+
+	contents, err := io.ReadAll(io.LimitReader(reader, networkLoadBalancerDefinitionMaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(contents) > networkLoadBalancerDefinitionMaxSize {
+		return nil, fmt.Errorf(i18n.G("Load balancer definition %q exceeds the maximum size of %d bytes"), source, networkLoadBalancerDefinitionMaxSize)
+	}
+
+	return contents, nil
 }
 
 // Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
 func (c *cmdNetworkLoadBalancerCreate) Command() *cobra.Command {
 	cmd := &cobra.Command{}
-	cmd.Use = usage("create", i18n.G("[<remote>:]<network> <listen_address> [key=value...]"))
+	cmd.Use = usage("create", i18n.G("[<remote>:]<network> <listen_address>... [key=value...]"))
 	cmd.Aliases = []string{"add"}
 	cmd.Short = i18n.G("Create new network load balancers")
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G("Create new network load balancers"))
 	cmd.Example = cli.FormatSection("", i18n.G(`incus network load-balancer create n1 127.0.0.1
 
 incus network load-balancer create n1 127.0.0.1 < config.yaml
-    Create network load-balancer for network n1 with configuration from config.yaml`))
+    Create network load-balancer for network n1 with configuration from config.yaml
+
+incus network load-balancer create n1 127.0.0.1 --from https://example.com/lb.yaml
+    Create network load-balancer for network n1 with configuration fetched from a URL
+
+incus network load-balancer create n1 192.0.2.10 192.0.2.11 192.0.2.12 --template lb.yaml
+    Create network load-balancers for network n1 on three listen addresses with the same configuration from lb.yaml`))
 
 	cmd.RunE = c.Run
 
 	cmd.Flags().StringVar(&c.networkLoadBalancer.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
 	cmd.Flags().StringVar(&c.flagDescription, "description", "", i18n.G("Load balancer description")+"``")
+	cmd.Flags().StringVar(&c.flagFrom, "from", "", i18n.G("Read the load balancer definition from a file or http(s) URL")+"``")
+	cmd.Flags().StringVar(&c.flagTemplate, "template", "", i18n.G("Create a load balancer on each listen address from the definition in a file or http(s) URL")+"``")
 
 	return cmd
 }
@@ -377,9 +627,43 @@ func (c *cmdNetworkLoadBalancerCreate) Run(cmd *cobra.Command, args []string) er
 		return errors.New(i18n.G("Missing listen address"))
 	}
 
-	// If stdin isn't a terminal, read yaml from it.
+	if c.flagFrom != "" && c.flagTemplate != "" {
+		return errors.New(i18n.G("--from and --template can't be used together"))
+	}
+
+	// With --template, every argument that isn't a key/value pair is a listen address.
+	listenAddresses := []string{args[1]}
+	keys := args[2:]
+	if c.flagTemplate != "" {
+		listenAddresses = []string{}
+		keys = []string{}
+		for _, arg := range args[1:] {
+			if strings.Contains(arg, "=") {
+				keys = append(keys, arg)
+			} else {
+				listenAddresses = append(listenAddresses, arg)
+			}
+		}
+	}
+
+	// Read the yaml from --from or --template if given, otherwise from stdin if it isn't a terminal.
 	var loadBalancerPut api.NetworkLoadBalancerPut
-	if !termios.IsTerminal(getStdinFd()) {
+	if c.flagFrom != "" || c.flagTemplate != "" {
+		source := c.flagFrom
+		if c.flagTemplate != "" {
+			source = c.flagTemplate
+		}
+
+		contents, err := c.readDefinition(source)
+		if err != nil {
+			return err
+		}
+
+		err = yaml.UnmarshalStrict(contents, &loadBalancerPut)
+		if err != nil {
+			return err
+		}
+	} else if !termios.IsTerminal(getStdinFd()) {
 		contents, err := io.ReadAll(os.Stdin)
 		if err != nil {
 			return err
@@ -396,27 +680,24 @@ func (c *cmdNetworkLoadBalancerCreate) Run(cmd *cobra.Command, args []string) er
 	}
 
 	// Get config filters from arguments.
-	for i := 2; i < len(args); i++ {
-		entry := strings.SplitN(args[i], "=", 2)
+	for _, key := range keys {
+		entry := strings.SplitN(key, "=", 2)
 		if len(entry) < 2 {
-			return fmt.Errorf(i18n.G("Bad key/value pair: %s"), args[i])
+			return fmt.Errorf(i18n.G("Bad key/value pair: %s"), key)
 		}
 
 		loadBalancerPut.Config[entry[0]] = entry[1]
 	}
 
-	// Create the network load balancer.
-	loadBalancer := api.NetworkLoadBalancersPost{
-		ListenAddress:          args[1],
-		NetworkLoadBalancerPut: loadBalancerPut,
+	err = validateNetworkLoadBalancerConfig(loadBalancerPut.Config)
+	if err != nil {
+		return err
 	}
 
-	if c.flagDescription != "" {
-		loadBalancer.Description = c.flagDescription
+	if len(listenAddresses) == 0 {
+		return errors.New(i18n.G("Missing listen address"))
 	}
 
-	loadBalancer.Normalise()
-
 	client := resource.server
 
 	// If a target was specified, create the load balancer on the given member.
@@ -424,13 +705,43 @@ func (c *cmdNetworkLoadBalancerCreate) Run(cmd *cobra.Command, args []string) er
 		client = client.UseTarget(c.networkLoadBalancer.flagTarget)
 	}
 
-	err = client.CreateNetworkLoadBalancer(resource.name, loadBalancer)
-	if err != nil {
-		return err
+	// Create the network load balancers, carrying on past failures when creating several.
+	failed := 0
+	for _, listenAddress := range listenAddresses {
+		loadBalancer := api.NetworkLoadBalancersPost{
+			ListenAddress:          listenAddress,
+			NetworkLoadBalancerPut: loadBalancerPut,
+		}
+
+		// Each load balancer gets its own copy of the template.
+		loadBalancer.Config = maps.Clone(loadBalancerPut.Config)
+		loadBalancer.Backends = slices.Clone(loadBalancerPut.Backends)
+		loadBalancer.Ports = slices.Clone(loadBalancerPut.Ports)
+
+		if c.flagDescription != "" {
+			loadBalancer.Description = c.flagDescription
+		}
+
+		loadBalancer.Normalise()
+
+		err = client.CreateNetworkLoadBalancer(resource.name, loadBalancer)
+		if err != nil {
+			if len(listenAddresses) == 1 {
+				return err
+			}
+
+			fmt.Fprintf(os.Stderr, i18n.G("Failed creating network load balancer %s: %v")+"\n", listenAddress, err)
+			failed++
+			continue
+		}
+
+		if !c.global.flagQuiet {
+			fmt.Printf(i18n.G("Network load balancer %s created")+"\n", loadBalancer.ListenAddress)
+		}
 	}
 
-	if !c.global.flagQuiet {
-		fmt.Printf(i18n.G("Network load balancer %s created")+"\n", loadBalancer.ListenAddress)
+	if failed > 0 {
+		return fmt.Errorf(i18n.G("Failed creating %d of %d network load balancers"), failed, len(listenAddresses))
 	}
 
 	return nil
@@ -610,6 +921,11 @@ func (c *cmdNetworkLoadBalancerSet) Run(cmd *cobra.Command, args []string) error
 		maps.Copy(writable.Config, keys)
 	}
 
+	err = validateNetworkLoadBalancerConfig(writable.Config)
+	if err != nil {
+		return err
+	}
+
 	writable.Normalise()
 
 	return client.UpdateNetworkLoadBalancer(resource.name, loadBalancer.ListenAddress, writable, etag)
@@ -691,6 +1007,9 @@ func (c *cmdNetworkLoadBalancerEdit) helpTemplate() string {
 ### An example would look like:
 ### listen_address: 192.0.2.1
 ### config:
+###   healthcheck: "true"
+###   healthcheck.interval: "10"
+###   healthcheck.failure_count: "3"
 ###   user.foo: bar
 ### description: test desc
 ### backends:
@@ -698,6 +1017,7 @@ func (c *cmdNetworkLoadBalancerEdit) helpTemplate() string {
 ###   description: First backend server
 ###   target_address: 192.0.3.1
 ###   target_port: 80
+###   weight: 2
 ### - name: backend2
 ###   description: Second backend server
 ###   target_address: 192.0.3.2
@@ -761,6 +1081,11 @@ func (c *cmdNetworkLoadBalancerEdit) Run(cmd *cobra.Command, args []string) erro
 			return err
 		}
 
+		err = validateNetworkLoadBalancerConfig(newData.Config)
+		if err != nil {
+			return err
+		}
+
 		newData.Normalise()
 
 		return client.UpdateNetworkLoadBalancer(resource.name, args[1], newData.NetworkLoadBalancerPut, "")
@@ -787,6 +1112,10 @@ func (c *cmdNetworkLoadBalancerEdit) Run(cmd *cobra.Command, args []string) erro
 		// Parse the text received from the editor.
 		newData := api.NetworkLoadBalancer{} // We show the full info, but only send the writable fields.
 		err = yaml.UnmarshalStrict(content, &newData)
+		if err == nil {
+			err = validateNetworkLoadBalancerConfig(newData.Config)
+		}
+
 		if err == nil {
 			newData.Normalise()
 			err = client.UpdateNetworkLoadBalancer(resource.name, args[1], newData.Writable(), etag)
@@ -820,6 +1149,8 @@ func (c *cmdNetworkLoadBalancerEdit) Run(cmd *cobra.Command, args []string) erro
 type cmdNetworkLoadBalancerDelete struct {
 	global              *cmdGlobal
 	networkLoadBalancer *cmdNetworkLoadBalancer
+
+	flagForce bool
 }
 
 // Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
@@ -828,10 +1159,15 @@ func (c *cmdNetworkLoadBalancerDelete) Command() *cobra.Command {
 	cmd.Use = usage("delete", i18n.G("[<remote>:]<network> <listen_address>"))
 	cmd.Aliases = []string{"rm", "remove"}
 	cmd.Short = i18n.G("Delete network load balancers")
-	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G("Delete network load balancers"))
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Delete network load balancers
+
+Unless --yes is passed, the deletion has to be confirmed interactively.`))
 	cmd.RunE = c.Run
 
 	cmd.Flags().StringVar(&c.networkLoadBalancer.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+	cmd.Flags().BoolVarP(&c.flagForce, "yes", "y", false, i18n.G("Delete without user confirmation"))
+	cmd.Flags().BoolVar(&c.flagForce, "force", false, i18n.G("Delete without user confirmation"))
 
 	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		if len(args) == 0 {
@@ -879,6 +1215,26 @@ func (c *cmdNetworkLoadBalancerDelete) Run(cmd *cobra.Command, args []string) er
 		client = client.UseTarget(c.networkLoadBalancer.flagTarget)
 	}
 
+	if !c.flagForce {
+		if !termios.IsTerminal(getStdinFd()) {
+			return errors.New(i18n.G("Deleting a load balancer non-interactively requires --yes"))
+		}
+
+		loadBalancer, _, err := client.GetNetworkLoadBalancer(resource.name, args[1])
+		if err != nil {
+			return err
+		}
+
+		confirm, err := c.global.asker.AskBool(fmt.Sprintf(i18n.G("Delete load balancer %s with %d backend(s) and %d port(s)? (yes/no) [default=no]: "), loadBalancer.ListenAddress, len(loadBalancer.Backends), len(loadBalancer.Ports)), "no")
+		if err != nil {
+			return err
+		}
+
+		if !confirm {
+			return nil
+		}
+	}
+
 	// Delete the network load balancer.
 	err = client.DeleteNetworkLoadBalancer(resource.name, args[1])
 	if err != nil {
@@ -892,11 +1248,12 @@ func (c *cmdNetworkLoadBalancerDelete) Run(cmd *cobra.Command, args []string) er
 	return nil
 }
 
-// Add/Remove Backend.
+// Add/Update/Remove Backend.
 type cmdNetworkLoadBalancerBackend struct {
 	global              *cmdGlobal
 	networkLoadBalancer *cmdNetworkLoadBalancer
 	flagDescription     string
+	flagWeight          int
 }
 
 // Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
@@ -909,6 +1266,9 @@ func (c *cmdNetworkLoadBalancerBackend) Command() *cobra.Command {
 	// Backend Add.
 	cmd.AddCommand(c.CommandAdd())
 
+	// Backend Update.
+	cmd.AddCommand(c.CommandUpdate())
+
 	// Backend Remove.
 	cmd.AddCommand(c.CommandRemove())
 
@@ -926,6 +1286,7 @@ func (c *cmdNetworkLoadBalancerBackend) CommandAdd() *cobra.Command {
 
 	cmd.Flags().StringVar(&c.networkLoadBalancer.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
 	cmd.Flags().StringVar(&c.flagDescription, "description", "", i18n.G("Backend description")+"``")
+	cmd.Flags().IntVar(&c.flagWeight, "weight", 0, i18n.G("Backend weight relative to the other backends")+"``")
 
 	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		if len(args) == 0 {
@@ -973,27 +1334,110 @@ func (c *cmdNetworkLoadBalancerBackend) RunAdd(cmd *cobra.Command, args []string
 		client = client.UseTarget(c.networkLoadBalancer.flagTarget)
 	}
 
-	// Get the network load balancer.
-	loadBalancer, etag, err := client.GetNetworkLoadBalancer(resource.name, args[1])
-	if err != nil {
-		return err
+	if cmd.Flags().Changed("weight") && c.flagWeight <= 0 {
+		return errors.New(i18n.G("Backend weight must be a positive integer"))
 	}
 
 	backend := api.NetworkLoadBalancerBackend{
 		Name:          args[2],
 		TargetAddress: args[3],
 		Description:   c.flagDescription,
+		Weight:        c.flagWeight,
 	}
 
 	if len(args) >= 5 {
 		backend.TargetPort = args[4]
 	}
 
-	loadBalancer.Backends = append(loadBalancer.Backends, backend)
+	return c.networkLoadBalancer.updateLoadBalancer(client, resource.name, args[1], func(loadBalancer *api.NetworkLoadBalancer) error {
+		loadBalancer.Backends = append(loadBalancer.Backends, backend)
+		return c.networkLoadBalancer.checkBackendPorts(loadBalancer)
+	})
+}
+
+// CommandUpdate returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdNetworkLoadBalancerBackend) CommandUpdate() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("update", i18n.G("[<remote>:]<network> <listen_address> <backend_name>"))
+	cmd.Short = i18n.G("Update backends of a load balancer")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G("Update backend of a load balancer"))
+	cmd.Example = cli.FormatSection("", i18n.G(`incus network load-balancer backend update n1 127.0.0.1 backend1 --weight 10
+    Give backend1 a weight of 10`))
+	cmd.RunE = c.RunUpdate
+
+	cmd.Flags().StringVar(&c.networkLoadBalancer.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+	cmd.Flags().StringVar(&c.flagDescription, "description", "", i18n.G("Backend description")+"``")
+	cmd.Flags().IntVar(&c.flagWeight, "weight", 0, i18n.G("Backend weight relative to the other backends")+"``")
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpNetworks(toComplete)
+		}
+
+		if len(args) == 1 {
+			return c.global.cmpNetworkLoadBalancers(args[0])
+		}
 
-	loadBalancer.Normalise()
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
 
-	return client.UpdateNetworkLoadBalancer(resource.name, loadBalancer.ListenAddress, loadBalancer.Writable(), etag)
+	return cmd
+}
+
+// RunUpdate runs the actual command logic.
+func (c *cmdNetworkLoadBalancerBackend) RunUpdate(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 3, 3)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing network name"))
+	}
+
+	if args[1] == "" {
+		return errors.New(i18n.G("Missing listen address"))
+	}
+
+	if cmd.Flags().Changed("weight") && c.flagWeight <= 0 {
+		return errors.New(i18n.G("Backend weight must be a positive integer"))
+	}
+
+	client := resource.server
+
+	// If a target was specified, use the load balancer on the given member.
+	if c.networkLoadBalancer.flagTarget != "" {
+		client = client.UseTarget(c.networkLoadBalancer.flagTarget)
+	}
+
+	return c.networkLoadBalancer.updateLoadBalancer(client, resource.name, args[1], func(loadBalancer *api.NetworkLoadBalancer) error {
+		for i, backend := range loadBalancer.Backends {
+			if backend.Name != args[2] {
+				continue
+			}
+
+			if cmd.Flags().Changed("description") {
+				loadBalancer.Backends[i].Description = c.flagDescription
+			}
+
+			if cmd.Flags().Changed("weight") {
+				loadBalancer.Backends[i].Weight = c.flagWeight
+			}
+
+			return nil
+		}
+
+		return errors.New(i18n.G("No matching backend found"))
+	})
 }
 
 // CommandRemove returns a cobra.Command for use with (*cobra.Command).AddCommand.
@@ -1053,12 +1497,6 @@ func (c *cmdNetworkLoadBalancerBackend) RunRemove(cmd *cobra.Command, args []str
 		client = client.UseTarget(c.networkLoadBalancer.flagTarget)
 	}
 
-	// Get the network load balancer.
-	loadBalancer, etag, err := client.GetNetworkLoadBalancer(resource.name, args[1])
-	if err != nil {
-		return err
-	}
-
 	// removeBackend removes a single backend that matches the filterArgs supplied.
 	removeBackend := func(backends []api.NetworkLoadBalancerBackend, removeName string) ([]api.NetworkLoadBalancerBackend, error) {
 		removed := false
@@ -1080,14 +1518,15 @@ func (c *cmdNetworkLoadBalancerBackend) RunRemove(cmd *cobra.Command, args []str
 		return newBackends, nil
 	}
 
-	loadBalancer.Backends, err = removeBackend(loadBalancer.Backends, args[2])
-	if err != nil {
-		return err
-	}
-
-	loadBalancer.Normalise()
+	return c.networkLoadBalancer.updateLoadBalancer(client, resource.name, args[1], func(loadBalancer *api.NetworkLoadBalancer) error {
+		backends, err := removeBackend(loadBalancer.Backends, args[2])
+		if err != nil {
+			return err
+		}
 
-	return client.UpdateNetworkLoadBalancer(resource.name, loadBalancer.ListenAddress, loadBalancer.Writable(), etag)
+		loadBalancer.Backends = backends
+		return nil
+	})
 }
 
 // Add/Remove Port.
@@ -1172,12 +1611,6 @@ func (c *cmdNetworkLoadBalancerPort) RunAdd(cmd *cobra.Command, args []string) e
 		client = client.UseTarget(c.networkLoadBalancer.flagTarget)
 	}
 
-	// Get the network load balancer.
-	loadBalancer, etag, err := client.GetNetworkLoadBalancer(resource.name, args[1])
-	if err != nil {
-		return err
-	}
-
 	port := api.NetworkLoadBalancerPort{
 		Protocol:      args[2],
 		ListenPort:    args[3],
@@ -1185,11 +1618,10 @@ func (c *cmdNetworkLoadBalancerPort) RunAdd(cmd *cobra.Command, args []string) e
 		Description:   c.flagDescription,
 	}
 
-	loadBalancer.Ports = append(loadBalancer.Ports, port)
-
-	loadBalancer.Normalise()
-
-	return client.UpdateNetworkLoadBalancer(resource.name, loadBalancer.ListenAddress, loadBalancer.Writable(), etag)
+	return c.networkLoadBalancer.updateLoadBalancer(client, resource.name, args[1], func(loadBalancer *api.NetworkLoadBalancer) error {
+		loadBalancer.Ports = append(loadBalancer.Ports, port)
+		return c.networkLoadBalancer.checkBackendPorts(loadBalancer)
+	})
 }
 
 // CommandRemove returns a cobra.Command for use with (*cobra.Command).AddCommand.
@@ -1250,12 +1682,6 @@ func (c *cmdNetworkLoadBalancerPort) RunRemove(cmd *cobra.Command, args []string
 		client = client.UseTarget(c.networkLoadBalancer.flagTarget)
 	}
 
-	// Get the network load balancer.
-	loadBalancer, etag, err := client.GetNetworkLoadBalancer(resource.name, args[1])
-	if err != nil {
-		return err
-	}
-
 	// isFilterMatch returns whether the supplied port has matching field values in the filterArgs supplied.
 	// If no filterArgs are supplied, then the rule is considered to have matched.
 	isFilterMatch := func(port *api.NetworkLoadBalancerPort, filterArgs []string) bool {
@@ -1301,20 +1727,49 @@ func (c *cmdNetworkLoadBalancerPort) RunRemove(cmd *cobra.Command, args []string
 		return newPorts, nil
 	}
 
-	loadBalancer.Ports, err = removeFromRules(loadBalancer.Ports, args[1:])
-	if err != nil {
-		return err
-	}
-
-	loadBalancer.Normalise()
+	return c.networkLoadBalancer.updateLoadBalancer(client, resource.name, args[1], func(loadBalancer *api.NetworkLoadBalancer) error {
+		ports, err := removeFromRules(loadBalancer.Ports, args[1:])
+		if err != nil {
+			return err
+		}
 
-	return client.UpdateNetworkLoadBalancer(resource.name, loadBalancer.ListenAddress, loadBalancer.Writable(), etag)
+		loadBalancer.Ports = ports
+		return nil
+	})
 }
 
 // Info.
 type cmdNetworkLoadBalancerInfo struct {
 	global              *cmdGlobal
 	networkLoadBalancer *cmdNetworkLoadBalancer
+
+	flagFormat  string
+	flagRefresh int
+}
+
+// networkLoadBalancerPortHealthEntry is the health of a single load balancer backend port.
+type networkLoadBalancerPortHealthEntry struct {
+	Backend  string `json:"backend" yaml:"backend"`
+	Address  string `json:"address" yaml:"address"`
+	Weight   int    `json:"weight" yaml:"weight"`
+	Protocol string `json:"protocol" yaml:"protocol"`
+	Port     int    `json:"port" yaml:"port"`
+
+	// State is the normalized status (up, down or unknown) while Status is the one reported by the server.
+	State  string `json:"state" yaml:"state"`
+	Status string `json:"status" yaml:"status"`
+}
+
+// networkLoadBalancerPortState normalizes the health check status reported for a backend port.
+func networkLoadBalancerPortState(status string) string {
+	switch status {
+	case "online":
+		return "up"
+	case "offline", "error":
+		return "down"
+	default:
+		return "unknown"
+	}
 }
 
 // Command generates the command definition.
@@ -1322,9 +1777,26 @@ func (c *cmdNetworkLoadBalancerInfo) Command() *cobra.Command {
 	cmd := &cobra.Command{}
 	cmd.Use = usage("info", i18n.G("[<remote>:]<network> <listen_address>"))
 	cmd.Short = i18n.G("Get current load balancer status")
-	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G("Get current load-balacner status"))
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Get current load-balancer status
+
+When --format is set, the health of each backend port is listed instead, with its
+state normalized to up, down or unknown.
+
+When --refresh is set, the status is redrawn at that interval until interrupted.`))
 	cmd.RunE = c.Run
 
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "", i18n.G(`Format (csv|json|table|yaml|compact|markdown), use suffix ",noheader" to disable headers and ",header" to enable it if missing, e.g. csv,header`)+"``")
+	cmd.Flags().IntVar(&c.flagRefresh, "refresh", 0, i18n.G("Redraw the status every given number of seconds")+"``")
+
+	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
+		if c.flagFormat == "" {
+			return nil
+		}
+
+		return cli.ValidateFlagFormatForListOutput(cmd.Flag("format").Value.String())
+	}
+
 	return cmd
 }
 
@@ -1353,8 +1825,42 @@ func (c *cmdNetworkLoadBalancerInfo) Run(cmd *cobra.Command, args []string) erro
 		return errors.New(i18n.G("Missing listen address"))
 	}
 
+	if c.flagRefresh < 0 {
+		return errors.New(i18n.G("The refresh interval can't be negative"))
+	}
+
+	if c.flagRefresh == 0 {
+		return c.render(client, resource.name, args[1])
+	}
+
+	// Keep redrawing until interrupted, showing errors in place so that transient failures don't end monitoring.
+	ticker := time.NewTicker(time.Duration(c.flagRefresh) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		fmt.Print("\033[H\033[2J") // Clear the terminal on each tick
+		err = c.render(client, resource.name, args[1])
+		if err != nil {
+			fmt.Printf(i18n.G("Error: %v")+"\n\n", err)
+		}
+
+		fmt.Println(i18n.G("Press CTRL-C to exit"))
+		fmt.Println(i18n.G("Delay:"), time.Duration(c.flagRefresh)*time.Second)
+
+		<-ticker.C
+	}
+}
+
+// render fetches the load balancer state and configuration and prints them.
+func (c *cmdNetworkLoadBalancerInfo) render(client incus.InstanceServer, network string, listenAddress string) error {
 	// Get the load-balancer state.
-	lbState, err := client.GetNetworkLoadBalancerState(resource.name, args[1])
+	lbState, err := client.GetNetworkLoadBalancerState(network, listenAddress)
+	if err != nil {
+		return err
+	}
+
+	// Get the load-balancer configuration.
+	loadBalancer, _, err := client.GetNetworkLoadBalancer(network, listenAddress)
 	if err != nil {
 		return err
 	}
@@ -1365,13 +1871,41 @@ func (c *cmdNetworkLoadBalancerInfo) Run(cmd *cobra.Command, args []string) erro
 		return errors.New(i18n.G("No load-balancer health information available"))
 	}
 
+	// Index the backend weights.
+	weights := make(map[string]int, len(loadBalancer.Backends))
+	for _, backend := range loadBalancer.Backends {
+		weights[backend.Name] = backend.Weight
+	}
+
+	if c.flagFormat != "" {
+		return c.renderPorts(lbState, weights)
+	}
+
+	if util.IsTrue(loadBalancer.Config["healthcheck"]) {
+		fmt.Println(i18n.G("Health check:"))
+		for _, key := range []string{"healthcheck.interval", "healthcheck.timeout", "healthcheck.success_count", "healthcheck.failure_count"} {
+			value := loadBalancer.Config[key]
+			if value == "" {
+				value = i18n.G("default")
+			}
+
+			fmt.Printf("  %s: %s\n", key, value)
+		}
+
+		fmt.Println("")
+	}
+
 	fmt.Println(i18n.G("Backend health:"))
 	for backend, info := range lbState.BackendHealth {
 		if len(info.Ports) == 0 {
 			continue
 		}
 
-		fmt.Printf("  %s (%s):\n", backend, info.Address)
+		if weights[backend] > 0 {
+			fmt.Printf("  %s (%s, "+i18n.G("weight %d")+"):\n", backend, info.Address, weights[backend])
+		} else {
+			fmt.Printf("  %s (%s):\n", backend, info.Address)
+		}
 		for _, port := range info.Ports {
 			fmt.Printf("    - %s/%d: %s\n", port.Protocol, port.Port, port.Status)
 		}
@@ -1381,3 +1915,166 @@ func (c *cmdNetworkLoadBalancerInfo) Run(cmd *cobra.Command, args []string) erro
 
 	return nil
 }
+
+// renderPorts lists the health of each backend port in the requested format.
+func (c *cmdNetworkLoadBalancerInfo) renderPorts(lbState *api.NetworkLoadBalancerState, weights map[string]int) error {
+	entries := []networkLoadBalancerPortHealthEntry{}
+	for backend, info := range lbState.BackendHealth {
+		for _, port := range info.Ports {
+			entries = append(entries, networkLoadBalancerPortHealthEntry{
+				Backend:  backend,
+				Address:  info.Address,
+				Weight:   weights[backend],
+				Protocol: port.Protocol,
+				Port:     port.Port,
+				State:    networkLoadBalancerPortState(port.Status),
+				Status:   port.Status,
+			})
+		}
+	}
+
+	sort.Slice(entries, func(i int, j int) bool {
+		if entries[i].Backend != entries[j].Backend {
+			return entries[i].Backend < entries[j].Backend
+		}
+
+		if entries[i].Port != entries[j].Port {
+			return entries[i].Port < entries[j].Port
+		}
+
+		return entries[i].Protocol < entries[j].Protocol
+	})
+
+	data := [][]string{}
+	for _, entry := range entries {
+		data = append(data, []string{entry.Backend, entry.Address, fmt.Sprintf("%s/%d", entry.Protocol, entry.Port), entry.State})
+	}
+
+	header := []string{
+		i18n.G("BACKEND"),
+		i18n.G("ADDRESS"),
+		i18n.G("PORT"),
+		i18n.G("STATE"),
+	}
+
+	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, entries)
+}
+
+// Health.
+type cmdNetworkLoadBalancerHealth struct {
+	global              *cmdGlobal
+	networkLoadBalancer *cmdNetworkLoadBalancer
+
+	flagFormat string
+}
+
+// networkLoadBalancerHealthEntry is the aggregated backend health of a load balancer.
+type networkLoadBalancerHealthEntry struct {
+	ListenAddress string `json:"listen_address" yaml:"listen_address"`
+	Status        string `json:"status" yaml:"status"`
+	OnlinePorts   int    `json:"online_ports" yaml:"online_ports"`
+	TotalPorts    int    `json:"total_ports" yaml:"total_ports"`
+}
+
+// Command generates the command definition.
+func (c *cmdNetworkLoadBalancerHealth) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("health", i18n.G("[<remote>:]<network>"))
+	cmd.Short = i18n.G("Show the backend health of all load balancers")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Show the backend health of all load balancers
+
+A load balancer is reported as healthy when all of its backend ports are online,
+degraded when only some of them are and down when none of them are.`))
+	cmd.RunE = c.Run
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", c.global.defaultListFormat(), i18n.G(`Format (csv|json|table|yaml|compact|markdown), use suffix ",noheader" to disable headers and ",header" to enable it if missing, e.g. csv,header`)+"``")
+
+	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
+		return cli.ValidateFlagFormatForListOutput(cmd.Flag("format").Value.String())
+	}
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpNetworks(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdNetworkLoadBalancerHealth) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+	client := resource.server
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing network name"))
+	}
+
+	loadBalancers, err := client.GetNetworkLoadBalancers(resource.name)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]networkLoadBalancerHealthEntry, 0, len(loadBalancers))
+	for _, loadBalancer := range loadBalancers {
+		lbState, err := client.GetNetworkLoadBalancerState(resource.name, loadBalancer.ListenAddress)
+		if err != nil {
+			return err
+		}
+
+		entry := networkLoadBalancerHealthEntry{ListenAddress: loadBalancer.ListenAddress}
+		for _, info := range lbState.BackendHealth {
+			for _, port := range info.Ports {
+				entry.TotalPorts++
+				if port.Status == "online" {
+					entry.OnlinePorts++
+				}
+			}
+		}
+
+		switch {
+		case entry.TotalPorts == 0:
+			entry.Status = "unknown"
+		case entry.OnlinePorts == entry.TotalPorts:
+			entry.Status = "healthy"
+		case entry.OnlinePorts > 0:
+			entry.Status = "degraded"
+		default:
+			entry.Status = "down"
+		}
+
+		entries = append(entries, entry)
+	}
+
+	// Render the table.
+	data := [][]string{}
+	for _, entry := range entries {
+		data = append(data, []string{entry.ListenAddress, entry.Status, fmt.Sprintf("%d/%d", entry.OnlinePorts, entry.TotalPorts)})
+	}
+
+	sort.Sort(cli.SortColumnsNaturally(data))
+
+	header := []string{
+		i18n.G("LISTEN ADDRESS"),
+		i18n.G("STATUS"),
+		i18n.G("ONLINE"),
+	}
+
+	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, entries)
+}
@@ -41,6 +41,10 @@ func (c *cmdStorage) Command() *cobra.Command {
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
 		`Manage storage pools and volumes`))
 
+	// Audit
+	storageAuditCmd := cmdStorageAudit{global: c.global, storage: c}
+	cmd.AddCommand(storageAuditCmd.Command())
+
 	// Create
 	storageCreateCmd := cmdStorageCreate{global: c.global, storage: c}
 	cmd.AddCommand(storageCreateCmd.Command())
@@ -65,6 +69,10 @@ func (c *cmdStorage) Command() *cobra.Command {
 	storageListCmd := cmdStorageList{global: c.global, storage: c}
 	cmd.AddCommand(storageListCmd.Command())
 
+	// Reconcile
+	storageReconcileCmd := cmdStorageReconcile{global: c.global, storage: c}
+	cmd.AddCommand(storageReconcileCmd.Command())
+
 	// Set
 	storageSetCmd := cmdStorageSet{global: c.global, storage: c}
 	cmd.AddCommand(storageSetCmd.Command())
@@ -91,6 +99,91 @@ func (c *cmdStorage) Command() *cobra.Command {
 	return cmd
 }
 
+// Audit.
+type cmdStorageAudit struct {
+	global  *cmdGlobal
+	storage *cmdStorage
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdStorageAudit) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("audit", i18n.G("[<remote>:]<pool>"))
+	cmd.Short = i18n.G("Audit storage pools for inconsistencies")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Audit storage pools for inconsistencies
+
+The audit only reports what it finds, nothing is repaired.`))
+
+	cmd.Flags().StringVar(&c.storage.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpStoragePools(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdStorageAudit) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing pool name"))
+	}
+
+	// Targeting
+	if c.storage.flagTarget != "" {
+		if !resource.server.IsClustered() {
+			return errors.New(i18n.G("To use --target, the destination remote must be a cluster"))
+		}
+
+		resource.server = resource.server.UseTarget(c.storage.flagTarget)
+	}
+
+	// Run the audit
+	op, err := resource.server.AuditStoragePool(resource.name)
+	if err != nil {
+		return err
+	}
+
+	err = op.Wait()
+	if err != nil {
+		return err
+	}
+
+	report := op.Get().Metadata
+	if len(report) == 0 {
+		return nil
+	}
+
+	data, err := yaml.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s", data)
+
+	return nil
+}
+
 // Create.
 type cmdStorageCreate struct {
 	global  *cmdGlobal
@@ -654,6 +747,11 @@ func (c *cmdStorageInfo) Run(cmd *cobra.Command, args []string) error {
 		poolinfo[infostring][spaceusedstring] = units.GetByteSizeStringIEC(int64(res.Space.Used), 2)
 	}
 
+	// Driver specific details, such as the version of its tools.
+	if len(res.DriverInfo) > 0 {
+		poolinfo[i18n.G("driver info")] = res.DriverInfo
+	}
+
 	poolinfodata, err := yaml.Marshal(poolinfo)
 	if err != nil {
 		return err
@@ -851,6 +949,85 @@ func (c *cmdStorageList) Run(cmd *cobra.Command, args []string) error {
 	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, pools)
 }
 
+// Reconcile.
+type cmdStorageReconcile struct {
+	global  *cmdGlobal
+	storage *cmdStorage
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdStorageReconcile) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("reconcile", i18n.G("[<remote>:]<pool>"))
+	cmd.Short = i18n.G("Reconcile the storage metadata of storage pool volumes")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Reconcile the storage metadata of storage pool volumes
+
+The metadata kept on the storage for the pool's custom volumes is repaired to match the database.
+Each change made is printed.`))
+
+	cmd.Flags().StringVar(&c.storage.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpStoragePools(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdStorageReconcile) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Missing pool name"))
+	}
+
+	// Targeting
+	if c.storage.flagTarget != "" {
+		if !resource.server.IsClustered() {
+			return errors.New(i18n.G("To use --target, the destination remote must be a cluster"))
+		}
+
+		resource.server = resource.server.UseTarget(c.storage.flagTarget)
+	}
+
+	// Run the reconciliation
+	op, err := resource.server.ReconcileStoragePool(resource.name)
+	if err != nil {
+		return err
+	}
+
+	err = op.Wait()
+	if err != nil {
+		return err
+	}
+
+	changes, _ := op.Get().Metadata["changes"].([]any)
+	for _, change := range changes {
+		fmt.Println(change)
+	}
+
+	return nil
+}
+
 // Set.
 type cmdStorageSet struct {
 	global  *cmdGlobal
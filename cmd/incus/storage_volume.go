@@ -1483,6 +1483,22 @@ func (c *cmdStorageVolumeInfo) Run(cmd *cobra.Command, args []string) error {
 		fmt.Printf(i18n.G("Created: %s")+"\n", vol.CreatedAt.Local().Format(dateLayout))
 	}
 
+	// Driver specific details, such as the origin of clones.
+	if volState != nil && len(volState.DriverInfo) > 0 {
+		fmt.Println("\n" + i18n.G("Driver info:"))
+
+		keys := make([]string, 0, len(volState.DriverInfo))
+		for k := range volState.DriverInfo {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			fmt.Printf("  %s: %s\n", k, volState.DriverInfo[k])
+		}
+	}
+
 	// List snapshots
 	firstSnapshot := true
 	if len(volSnapshots) > 0 {
@@ -3215,6 +3231,7 @@ type cmdStorageVolumeExport struct {
 	flagVolumeOnly           bool
 	flagOptimizedStorage     bool
 	flagCompressionAlgorithm string
+	flagFormat               string
 }
 
 // Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
@@ -3223,9 +3240,22 @@ func (c *cmdStorageVolumeExport) Command() *cobra.Command {
 	cmd.Use = usage("export", i18n.G("[<remote>:]<pool> <volume> [<path>]"))
 	cmd.Short = i18n.G("Export custom storage volume")
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
-		`Export custom storage volume`))
+		`Export custom storage volume
+
+With --format=qcow2, a custom block volume or a virtual machine volume
+(or one of their snapshots, as <volume>/<snapshot>) is exported as a
+qcow2 disk image instead of a backup.`))
+	cmd.Example = cli.FormatSection("", i18n.G(`incus storage volume export default data data.tar.gz
+    Export the "data" custom volume as a backup
+
+incus storage volume export default vmdisk/snap0 vmdisk.qcow2 --format=qcow2
+    Export the "snap0" snapshot of the "vmdisk" block volume as a qcow2 disk image
+
+incus storage volume export default virtual-machine/vm1 vm1.qcow2 --format=qcow2
+    Export the root disk of the "vm1" virtual machine as a qcow2 disk image`))
 
 	cmd.Flags().BoolVar(&c.flagVolumeOnly, "volume-only", false, i18n.G("Export the volume without its snapshots"))
+	cmd.Flags().StringVar(&c.flagFormat, "format", "backup", i18n.G("Export format (backup or qcow2)")+"``")
 	cmd.Flags().BoolVar(&c.flagOptimizedStorage, "optimized-storage", false,
 		i18n.G("Use storage driver optimized format (can only be restored on a similar pool)"))
 	cmd.Flags().StringVar(&c.flagCompressionAlgorithm, "compression", "", i18n.G("Define a compression algorithm: for backup or none")+"``")
@@ -3276,6 +3306,19 @@ func (c *cmdStorageVolumeExport) Run(cmd *cobra.Command, args []string) error {
 	volumeOnly := c.flagVolumeOnly
 
 	volName, volType := parseVolume("custom", args[1])
+
+	switch c.flagFormat {
+	case "backup":
+	case "qcow2":
+		if !slices.Contains([]string{"custom", "virtual-machine"}, volType) {
+			return errors.New(i18n.G("Only \"custom\" and \"virtual-machine\" volumes can be exported as a disk image"))
+		}
+
+		return c.exportDisk(d, name, volType, volName, args[2:])
+	default:
+		return fmt.Errorf(i18n.G("Invalid export format %q"), c.flagFormat)
+	}
+
 	if volType != "custom" {
 		return errors.New(i18n.G("Only \"custom\" volumes can be exported"))
 	}
@@ -3376,6 +3419,43 @@ func (c *cmdStorageVolumeExport) Run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// exportDisk downloads the custom or virtual machine block volume (or snapshot) as a qcow2 disk image.
+func (c *cmdStorageVolumeExport) exportDisk(d incus.InstanceServer, pool string, volType string, volName string, args []string) error {
+	volName, snapName, _ := api.GetParentAndSnapshotName(volName)
+
+	targetName := volName + ".qcow2"
+	if len(args) > 0 {
+		targetName = args[0]
+	}
+
+	target, err := os.Create(targetName)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = target.Close() }()
+
+	progress := cli.ProgressRenderer{
+		Format: i18n.G("Exporting the disk image: %s"),
+		Quiet:  c.global.flagQuiet,
+	}
+
+	diskFileRequest := incus.BackupFileRequest{
+		BackupFile:      io.WriteSeeker(target),
+		ProgressHandler: progress.UpdateProgress,
+	}
+
+	_, err = d.GetStorageVolumeDiskFile(pool, volType, volName, snapName, &diskFileRequest)
+	if err != nil {
+		_ = os.Remove(targetName)
+		progress.Done("")
+		return fmt.Errorf(i18n.G("Failed to fetch storage volume disk image: %w"), err)
+	}
+
+	progress.Done(i18n.G("Disk image exported successfully!"))
+	return nil
+}
+
 // Import.
 type cmdStorageVolumeImport struct {
 	global        *cmdGlobal
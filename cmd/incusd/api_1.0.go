@@ -78,6 +78,7 @@ var api10 = []APIEndpoint{
 	imageCmd,
 	imageExportCmd,
 	imageRefreshCmd,
+	imageCacheCmd,
 	imagesCmd,
 	imageSecretCmd,
 	metadataConfigurationCmd,
@@ -115,6 +116,8 @@ var api10 = []APIEndpoint{
 	projectStateCmd,
 	projectAccessCmd,
 	storagePoolCmd,
+	storagePoolAuditCmd,
+	storagePoolReconcileCmd,
 	storagePoolResourcesCmd,
 	storagePoolsCmd,
 	storagePoolBucketsCmd,
@@ -134,6 +137,7 @@ var api10 = []APIEndpoint{
 	storagePoolVolumeTypeCustomBackupCmd,
 	storagePoolVolumeTypeCustomBackupExportCmd,
 	storagePoolVolumeTypeStateCmd,
+	storagePoolVolumeTypeDiskCmd,
 	warningsCmd,
 	warningCmd,
 	metricsCmd,
@@ -1,13 +1,24 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"net"
 	"net/http"
 	"net/http/httputil"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
 
 	"github.com/lxc/incus/v6/internal/server/auth"
+	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/response"
 )
 
@@ -21,6 +32,93 @@ var apiOS = APIEndpoint{
 	Head:   APIEndpointAction{Handler: apiOSProxy, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
 }
 
+// apiOSPaths lists the Incus OS API paths which are proxied, along with everything below them.
+// Extend it as new Incus OS endpoints become stable.
+var apiOSPaths = []string{
+	"1.0/applications",
+	"1.0/debug",
+	"1.0/services",
+	"1.0/system",
+}
+
+// apiOSPathAllowed returns whether the Incus OS API path may be proxied.
+func apiOSPathAllowed(name string) bool {
+	name = strings.Trim(name, "/")
+
+	// Reject any attempt at escaping the allowed paths.
+	if path.Clean("/"+name) != "/"+name {
+		return false
+	}
+
+	// The API root is proxied on its own, without anything below it.
+	if name == "1.0" {
+		return true
+	}
+
+	for _, allowed := range apiOSPaths {
+		if name == allowed || strings.HasPrefix(name, allowed+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// apiOSCacheablePaths lists the Incus OS status endpoints whose GET responses are briefly cached.
+var apiOSCacheablePaths = []string{
+	"1.0",
+	"1.0/system/resources",
+}
+
+// apiOSCacheTTL is how long a cached Incus OS response is served.
+const apiOSCacheTTL = 5 * time.Second
+
+type apiOSCacheEntry struct {
+	path    string
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+// apiOSCache holds the cached Incus OS responses, keyed by requestor and path so that responses are never
+// shared between clients.
+var apiOSCache = struct {
+	mu      sync.Mutex
+	entries map[string]apiOSCacheEntry
+}{entries: map[string]apiOSCacheEntry{}}
+
+// apiOSCacheInvalidate drops the cached responses of the paths related to a modified path.
+func apiOSCacheInvalidate(name string) {
+	apiOSCache.mu.Lock()
+	defer apiOSCache.mu.Unlock()
+
+	for key, entry := range apiOSCache.entries {
+		if entry.path == name || strings.HasPrefix(name, entry.path+"/") || strings.HasPrefix(entry.path, name+"/") {
+			delete(apiOSCache.entries, key)
+		}
+	}
+}
+
+// apiOSRecorder passes a response through while keeping a copy of it.
+type apiOSRecorder struct {
+	http.ResponseWriter
+
+	status int
+	body   bytes.Buffer
+}
+
+// WriteHeader records the status code.
+func (w *apiOSRecorder) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write records the body.
+func (w *apiOSRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
 func apiOSProxy(d *Daemon, r *http.Request) response.Response {
 	s := d.State()
 
@@ -35,6 +133,27 @@ func apiOSProxy(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(errors.New("System isn't running Incus OS"))
 	}
 
+	// Only forward the known Incus OS endpoints.
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if !apiOSPathAllowed(name) {
+		return response.NotFound(fmt.Errorf("Incus OS endpoint %q isn't supported", "/"+strings.Trim(name, "/")))
+	}
+
+	name = strings.Trim(name, "/")
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		apiOSCacheInvalidate(name)
+	}
+
+	var cacheKey string
+	if r.Method == http.MethodGet && r.URL.RawQuery == "" && slices.Contains(apiOSCacheablePaths, name) {
+		requestor := request.CreateRequestor(r)
+		cacheKey = fmt.Sprintf("%s/%s/%s", requestor.Protocol, requestor.Username, name)
+	}
+
 	// Prepare the proxy.
 	proxy := &httputil.ReverseProxy{
 		Transport: &http.Transport{
@@ -50,7 +169,42 @@ func apiOSProxy(d *Daemon, r *http.Request) response.Response {
 
 	// Handle the request.
 	return response.ManualResponse(func(w http.ResponseWriter) error {
-		http.StripPrefix("/os", proxy).ServeHTTP(w, r)
+		if cacheKey == "" {
+			http.StripPrefix("/os", proxy).ServeHTTP(w, r)
+
+			return nil
+		}
+
+		// Serve from the cache if possible.
+		apiOSCache.mu.Lock()
+		entry, ok := apiOSCache.entries[cacheKey]
+		apiOSCache.mu.Unlock()
+
+		if ok && time.Now().Before(entry.expires) {
+			for key, values := range entry.header {
+				w.Header()[key] = values
+			}
+
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write(entry.body)
+
+			return err
+		}
+
+		recorder := &apiOSRecorder{ResponseWriter: w, status: http.StatusOK}
+		http.StripPrefix("/os", proxy).ServeHTTP(recorder, r)
+
+		if recorder.status == http.StatusOK {
+			apiOSCache.mu.Lock()
+			apiOSCache.entries[cacheKey] = apiOSCacheEntry{
+				path:    name,
+				header:  w.Header().Clone(),
+				body:    recorder.body.Bytes(),
+				expires: time.Now().Add(apiOSCacheTTL),
+			}
+
+			apiOSCache.mu.Unlock()
+		}
 
 		return nil
 	})
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestAPIOSPathAllowed(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed bool
+	}{
+		{name: "1.0", allowed: true},
+		{name: "/1.0/", allowed: true},
+		{name: "1.0/applications", allowed: true},
+		{name: "1.0/applications/incus", allowed: true},
+		{name: "1.0/system/resources", allowed: true},
+		{name: "1.0/services/lvm", allowed: true},
+		{name: "1.0/debug/log", allowed: true},
+		{name: "", allowed: false},
+		{name: "1.0/unknown", allowed: false},
+		{name: "1.0/systemd", allowed: false},
+		{name: "1.0/applications/../secrets", allowed: false},
+		{name: "1.0/./system", allowed: false},
+		{name: "2.0/system", allowed: false},
+	}
+
+	for _, tt := range tests {
+		allowed := apiOSPathAllowed(tt.name)
+		if allowed != tt.allowed {
+			t.Errorf("apiOSPathAllowed(%q) = %v, expected %v", tt.name, allowed, tt.allowed)
+		}
+	}
+}
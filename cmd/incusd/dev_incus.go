@@ -9,14 +9,19 @@ import (
 	"net/url"
 	"os"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gorilla/mux"
 	"golang.org/x/sys/unix"
+	"gopkg.in/yaml.v2"
 
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/internal/linux"
+	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
 	"github.com/lxc/incus/v6/internal/server/events"
 	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
@@ -33,15 +38,21 @@ import (
 	"github.com/lxc/incus/v6/shared/ws"
 )
 
+// devIncusMaxBodySize is the maximum size of a request body sent to the /dev/incus API.
+const devIncusMaxBodySize = 1024 * 1024
+
 type hoistFunc func(f func(*Daemon, instance.Instance, http.ResponseWriter, *http.Request) response.Response, d *Daemon) func(http.ResponseWriter, *http.Request)
 
 // DevIncusServer creates an http.Server capable of handling requests against the
 // /dev/incus Unix socket endpoint created inside containers.
 func devIncusServer(d *Daemon) *http.Server {
+	// No read or write timeout is set as those would cut the long-lived events and websocket connections.
 	return &http.Server{
-		Handler:     devIncusAPI(d, hoistReq),
-		ConnState:   pidMapper.ConnStateHandler,
-		ConnContext: request.SaveConnectionInContext,
+		Handler:           http.MaxBytesHandler(devIncusAPI(d, hoistReq), devIncusMaxBodySize),
+		ConnState:         pidMapper.ConnStateHandler,
+		ConnContext:       request.SaveConnectionInContext,
+		ReadHeaderTimeout: 10 * time.Second,
+		IdleTimeout:       30 * time.Second,
 	}
 }
 
@@ -131,6 +142,10 @@ var devIncusEventsGet = devIncusHandler{"/1.0/events", func(d *Daemon, c instanc
 		return response.DevIncusErrorResponse(api.StatusErrorf(http.StatusForbidden, "not authorized"), c.Type() == instancetype.VM)
 	}
 
+	if util.IsFalse(c.ExpandedConfig()["security.guestapi.events"]) {
+		return response.DevIncusErrorResponse(api.StatusErrorf(http.StatusForbidden, "not authorized"), c.Type() == instancetype.VM)
+	}
+
 	typeStr := r.FormValue("type")
 	if typeStr == "" {
 		typeStr = "config,device"
@@ -241,23 +256,177 @@ var devIncusAPIHandler = devIncusHandler{"/1.0", func(d *Daemon, c instance.Inst
 	return response.DevIncusErrorResponse(api.StatusErrorf(http.StatusMethodNotAllowed, "%s", fmt.Sprintf("method %q not allowed", r.Method)), c.Type() == instancetype.VM)
 }}
 
+var devIncusCloudInitStatus = devIncusHandler{"/1.0/cloud-init/status", func(d *Daemon, c instance.Instance, w http.ResponseWriter, r *http.Request) response.Response {
+	if util.IsFalse(c.ExpandedConfig()["security.guestapi"]) {
+		return response.DevIncusErrorResponse(api.StatusErrorf(http.StatusForbidden, "not authorized"), c.Type() == instancetype.VM)
+	}
+
+	if r.Method == "GET" {
+		status := apiGuest.DevIncusCloudInitStatus{Status: c.LocalConfig()["volatile.cloud-init.status"]}
+
+		return response.DevIncusResponse(http.StatusOK, status, "json", c.Type() == instancetype.VM)
+	} else if r.Method == "PATCH" {
+		req := apiGuest.DevIncusCloudInitStatus{}
+
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			return response.DevIncusErrorResponse(api.StatusErrorf(http.StatusBadRequest, "%s", err.Error()), c.Type() == instancetype.VM)
+		}
+
+		if !slices.Contains(internalInstance.CloudInitStatuses, req.Status) {
+			return response.DevIncusErrorResponse(api.StatusErrorf(http.StatusBadRequest, "Invalid cloud-init status %q", req.Status), c.Type() == instancetype.VM)
+		}
+
+		// Only record and announce actual changes.
+		if c.LocalConfig()["volatile.cloud-init.status"] == req.Status {
+			return response.DevIncusResponse(http.StatusOK, "", "raw", c.Type() == instancetype.VM)
+		}
+
+		err = c.VolatileSet(map[string]string{"volatile.cloud-init.status": req.Status})
+		if err != nil {
+			return response.DevIncusErrorResponse(api.StatusErrorf(http.StatusInternalServerError, "%s", err.Error()), c.Type() == instancetype.VM)
+		}
+
+		d.State().Events.SendLifecycle(c.Project().Name, lifecycle.InstanceCloudInitStatusUpdated.Event(c, map[string]any{"status": req.Status}))
+
+		return response.DevIncusResponse(http.StatusOK, "", "raw", c.Type() == instancetype.VM)
+	}
+
+	return response.DevIncusErrorResponse(api.StatusErrorf(http.StatusMethodNotAllowed, "%s", fmt.Sprintf("method %q not allowed", r.Method)), c.Type() == instancetype.VM)
+}}
+
 var devIncusDevicesGet = devIncusHandler{"/1.0/devices", func(d *Daemon, c instance.Instance, w http.ResponseWriter, r *http.Request) response.Response {
 	if util.IsFalse(c.ExpandedConfig()["security.guestapi"]) {
 		return response.DevIncusErrorResponse(api.StatusErrorf(http.StatusForbidden, "not authorized"), c.Type() == instancetype.VM)
 	}
 
-	// Populate NIC hwaddr from volatile if not explicitly specified.
-	// This is so cloud-init running inside the instance can identify the NIC when the interface name is
-	// different than the device name (such as when run inside a VM).
-	localConfig := c.LocalConfig()
-	devices := c.ExpandedDevices()
+	if util.IsFalse(c.ExpandedConfig()["security.guestapi.devices"]) {
+		return response.DevIncusErrorResponse(api.StatusErrorf(http.StatusForbidden, "not authorized"), c.Type() == instancetype.VM)
+	}
+
+	return response.DevIncusResponse(http.StatusOK, devIncusDevices(c), "json", c.Type() == instancetype.VM)
+}}
+
+// devIncusVMOnlyKeys lists the device keys, per device type, that only apply to virtual machines.
+var devIncusVMOnlyKeys = map[string][]string{
+	"disk": {"boot.priority", "io.bus", "io.cache", "wwn"},
+	"nic":  {"boot.priority"},
+}
+
+// devIncusContainerOnlyKeys lists the device keys, per device type, that only apply to containers.
+var devIncusContainerOnlyKeys = map[string][]string{
+	"disk": {"shift"},
+}
+
+// devIncusDevices returns the instance's devices as seen from inside the instance.
+func devIncusDevices(c instance.Instance) deviceConfig.Devices {
+	return devIncusDevicesForType(c.Type(), c.ExpandedDevices(), c.LocalConfig())
+}
+
+// devIncusDevicesForType returns a copy of the devices tailored to the instance type.
+// The NIC hwaddr is populated from volatile if not explicitly specified. This is so cloud-init running inside
+// the instance can identify the NIC when the interface name is different than the device name (such as when
+// run inside a VM). Keys which don't apply to the instance type are omitted.
+func devIncusDevicesForType(instType instancetype.Type, expandedDevices deviceConfig.Devices, localConfig map[string]string) deviceConfig.Devices {
+	omitKeys := devIncusContainerOnlyKeys
+	if instType == instancetype.Container {
+		omitKeys = devIncusVMOnlyKeys
+	}
+
+	devices := expandedDevices.Clone()
 	for devName, devConfig := range devices {
 		if devConfig["type"] == "nic" && devConfig["hwaddr"] == "" && localConfig[fmt.Sprintf("volatile.%s.hwaddr", devName)] != "" {
-			devices[devName]["hwaddr"] = localConfig[fmt.Sprintf("volatile.%s.hwaddr", devName)]
+			devConfig["hwaddr"] = localConfig[fmt.Sprintf("volatile.%s.hwaddr", devName)]
+		}
+
+		for _, key := range omitKeys[devConfig["type"]] {
+			delete(devConfig, key)
 		}
 	}
 
-	return response.DevIncusResponse(http.StatusOK, c.ExpandedDevices(), "json", c.Type() == instancetype.VM)
+	return devices
+}
+
+// devIncusNetworkConfigEthernet is an interface entry of a cloud-init network-config (version 2).
+type devIncusNetworkConfigEthernet struct {
+	Match     map[string]string `yaml:"match"`
+	SetName   string            `yaml:"set-name"`
+	DHCP4     bool              `yaml:"dhcp4"`
+	Addresses []string          `yaml:"addresses,omitempty"`
+	Routes    []map[string]any  `yaml:"routes,omitempty"`
+}
+
+// devIncusNetworkConfig generates a cloud-init network-config (version 2) for the NICs of the instance.
+// NICs are matched on their MAC address and so are skipped when it isn't known yet. Routed NICs get their
+// addresses statically configured, all others are configured through DHCP.
+func devIncusNetworkConfig(devices deviceConfig.Devices) (string, error) {
+	ethernets := map[string]devIncusNetworkConfigEthernet{}
+	for _, dev := range devices.Sorted() {
+		devConfig := dev.Config
+		if devConfig["type"] != "nic" || devConfig["hwaddr"] == "" {
+			continue
+		}
+
+		name := devConfig["name"]
+		if name == "" {
+			name = dev.Name
+		}
+
+		ethernet := devIncusNetworkConfigEthernet{
+			Match:   map[string]string{"macaddress": strings.ToLower(devConfig["hwaddr"])},
+			SetName: name,
+		}
+
+		if devConfig["nictype"] == "routed" {
+			for _, address := range util.SplitNTrimSpace(devConfig["ipv4.address"], ",", -1, true) {
+				ethernet.Addresses = append(ethernet.Addresses, address+"/32")
+			}
+
+			if devConfig["ipv4.address"] != "" {
+				ethernet.Routes = append(ethernet.Routes, map[string]any{"to": "0.0.0.0/0", "via": "169.254.0.1", "on-link": true})
+			}
+
+			for _, address := range util.SplitNTrimSpace(devConfig["ipv6.address"], ",", -1, true) {
+				ethernet.Addresses = append(ethernet.Addresses, address+"/128")
+			}
+
+			if devConfig["ipv6.address"] != "" {
+				ethernet.Routes = append(ethernet.Routes, map[string]any{"to": "::/0", "via": "fe80::1", "on-link": true})
+			}
+		} else {
+			ethernet.DHCP4 = true
+		}
+
+		ethernets[name] = ethernet
+	}
+
+	out, err := yaml.Marshal(map[string]any{"version": 2, "ethernets": ethernets})
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+var devIncusNetworkConfigGet = devIncusHandler{"/1.0/network-config", func(d *Daemon, c instance.Instance, w http.ResponseWriter, r *http.Request) response.Response {
+	if util.IsFalse(c.ExpandedConfig()["security.guestapi"]) {
+		return response.DevIncusErrorResponse(api.StatusErrorf(http.StatusForbidden, "not authorized"), c.Type() == instancetype.VM)
+	}
+
+	// A network configuration provided by the user takes precedence.
+	for _, key := range []string{"cloud-init.network-config", "user.network-config"} {
+		value := c.ExpandedConfig()[key]
+		if value != "" {
+			return response.DevIncusResponse(http.StatusOK, value, "raw", c.Type() == instancetype.VM)
+		}
+	}
+
+	networkConfig, err := devIncusNetworkConfig(devIncusDevices(c))
+	if err != nil {
+		return response.DevIncusErrorResponse(api.StatusErrorf(http.StatusInternalServerError, "%s", err.Error()), c.Type() == instancetype.VM)
+	}
+
+	return response.DevIncusResponse(http.StatusOK, networkConfig, "raw", c.Type() == instancetype.VM)
 }}
 
 var handlers = []devIncusHandler{
@@ -268,9 +437,11 @@ var handlers = []devIncusHandler{
 	devIncusConfigGet,
 	devIncusConfigKeyGet,
 	devIncusMetadataGet,
+	devIncusNetworkConfigGet,
 	devIncusEventsGet,
 	devIncusImageExport,
 	devIncusDevicesGet,
+	devIncusCloudInitStatus,
 }
 
 func hoistReq(f func(*Daemon, instance.Instance, http.ResponseWriter, *http.Request) response.Response, d *Daemon) func(http.ResponseWriter, *http.Request) {
@@ -440,6 +611,11 @@ func findContainerForPid(pid int32, s *state.State) (instance.Container, error)
 				return nil, err
 			}
 
+			// The monitor belongs to an instance which is now recorded as running on another member (e.g. after a migration).
+			if s.ServerClustered && inst.Location() != s.ServerName {
+				return nil, fmt.Errorf("Instance %q in project %q is running on cluster member %q, not on %q", name, projectName, inst.Location(), s.ServerName)
+			}
+
 			if inst.Type() != instancetype.Container {
 				return nil, errors.New("Instance is not container type")
 			}
@@ -14,6 +14,8 @@ import (
 	"golang.org/x/sys/unix"
 
 	"github.com/lxc/incus/v6/internal/linux"
+	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
 	"github.com/lxc/incus/v6/internal/server/sys"
 )
 
@@ -173,3 +175,84 @@ func TestHttpRequest(t *testing.T) {
 		t.Fatal("resp error not expected: ", string(resp))
 	}
 }
+
+func TestDevIncusNetworkConfig(t *testing.T) {
+	devices := deviceConfig.Devices{
+		"eth0": {"type": "nic", "nictype": "bridged", "parent": "incusbr0", "hwaddr": "00:16:3E:00:00:01"},
+		"eth1": {"type": "nic", "nictype": "routed", "name": "ens5", "hwaddr": "00:16:3e:00:00:02", "ipv4.address": "192.0.2.10", "ipv6.address": "2001:db8::10"},
+		"eth2": {"type": "nic", "nictype": "bridged", "parent": "incusbr0"},
+		"root": {"type": "disk", "path": "/", "pool": "default"},
+	}
+
+	out, err := devIncusNetworkConfig(devices)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `ethernets:
+  ens5:
+    match:
+      macaddress: 00:16:3e:00:00:02
+    set-name: ens5
+    dhcp4: false
+    addresses:
+    - 192.0.2.10/32
+    - 2001:db8::10/128
+    routes:
+    - on-link: true
+      to: 0.0.0.0/0
+      via: 169.254.0.1
+    - on-link: true
+      to: ::/0
+      via: fe80::1
+  eth0:
+    match:
+      macaddress: 00:16:3e:00:00:01
+    set-name: eth0
+    dhcp4: true
+version: 2
+`
+
+	if out != expected {
+		t.Fatalf("Unexpected network config:\n%s", out)
+	}
+}
+
+func TestDevIncusDevicesForType(t *testing.T) {
+	expandedDevices := deviceConfig.Devices{
+		"eth0": {"type": "nic", "nictype": "bridged", "parent": "incusbr0", "boot.priority": "10"},
+		"eth1": {"type": "nic", "nictype": "bridged", "parent": "incusbr0", "hwaddr": "00:16:3e:00:00:02"},
+		"data": {"type": "disk", "path": "/data", "source": "/srv/data", "shift": "true", "io.bus": "nvme"},
+	}
+
+	localConfig := map[string]string{
+		"volatile.eth0.hwaddr": "00:16:3e:00:00:01",
+		"volatile.eth1.hwaddr": "00:16:3e:00:00:03",
+	}
+
+	vmDevices := devIncusDevicesForType(instancetype.VM, expandedDevices, localConfig)
+	if vmDevices["eth0"]["hwaddr"] != "00:16:3e:00:00:01" {
+		t.Fatalf("Expected VM NIC to carry its volatile hwaddr, got %q", vmDevices["eth0"]["hwaddr"])
+	}
+
+	if vmDevices["eth1"]["hwaddr"] != "00:16:3e:00:00:02" {
+		t.Fatalf("Expected VM NIC to keep its configured hwaddr, got %q", vmDevices["eth1"]["hwaddr"])
+	}
+
+	if vmDevices["data"]["io.bus"] != "nvme" || vmDevices["data"]["shift"] != "" {
+		t.Fatalf("Unexpected VM disk config: %v", vmDevices["data"])
+	}
+
+	containerDevices := devIncusDevicesForType(instancetype.Container, expandedDevices, localConfig)
+	if containerDevices["eth0"]["boot.priority"] != "" || containerDevices["eth0"]["hwaddr"] != "00:16:3e:00:00:01" {
+		t.Fatalf("Unexpected container NIC config: %v", containerDevices["eth0"])
+	}
+
+	if containerDevices["data"]["io.bus"] != "" || containerDevices["data"]["shift"] != "true" {
+		t.Fatalf("Unexpected container disk config: %v", containerDevices["data"])
+	}
+
+	if expandedDevices["eth0"]["hwaddr"] != "" || expandedDevices["data"]["shift"] != "true" {
+		t.Fatal("Expanded devices were modified")
+	}
+}
@@ -98,6 +98,12 @@ var imageRefreshCmd = APIEndpoint{
 	Post: APIEndpointAction{Handler: imageRefresh, AccessHandler: allowPermission(auth.ObjectTypeImage, auth.EntitlementCanEdit, "fingerprint")},
 }
 
+var imageCacheCmd = APIEndpoint{
+	Path: "images/{fingerprint}/cache",
+
+	Post: APIEndpointAction{Handler: imageCachePost, AccessHandler: allowPermission(auth.ObjectTypeImage, auth.EntitlementCanEdit, "fingerprint")},
+}
+
 var imageAliasesCmd = APIEndpoint{
 	Path: "images/aliases",
 
@@ -4687,6 +4693,111 @@ func imageRefresh(d *Daemon, r *http.Request) response.Response {
 	return operations.OperationResponse(op)
 }
 
+// swagger:operation POST /1.0/images/{fingerprint}/cache images images_cache_post
+//
+//	Cache an image in a storage pool
+//
+//	Creates the optimized image volume for the image in the given storage pool
+//	ahead of time, so that creating instances from it is fast. Nothing is done
+//	if the image is already cached in the pool or if the pool's driver doesn't
+//	use optimized image volumes.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	  - in: body
+//	    name: image
+//	    description: Storage pool to cache the image in
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/ImageCachePost"
+//	responses:
+//	  "202":
+//	    $ref: "#/responses/Operation"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func imageCachePost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// Cache the image on the requested cluster member.
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	projectName := request.ProjectParam(r)
+	fingerprint, err := url.PathUnescape(mux.Vars(r)["fingerprint"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	req := api.ImageCachePost{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if req.Pool == "" {
+		return response.BadRequest(errors.New("No storage pool specified"))
+	}
+
+	var imageInfo *api.Image
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		_, imageInfo, err = tx.GetImage(ctx, fingerprint, dbCluster.ImageFilter{Project: &projectName})
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	pool, err := storagePools.LoadByName(s, req.Pool)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	run := func(op *operations.Operation) error {
+		// The image file may only be present on another cluster member.
+		err := ensureImageIsLocallyAvailable(context.TODO(), s, r, imageInfo, projectName)
+		if err != nil {
+			return err
+		}
+
+		return pool.EnsureImage(imageInfo.Fingerprint, op)
+	}
+
+	resources := map[string][]api.URL{}
+	resources["images"] = []api.URL{*api.NewURL().Path(version.APIVersion, "images", imageInfo.Fingerprint)}
+	resources["storage_pools"] = []api.URL{*api.NewURL().Path(version.APIVersion, "storage-pools", req.Pool)}
+
+	op, err := operations.OperationCreate(s, projectName, operations.OperationClassTask, operationtype.ImageCache, resources, nil, run, nil, nil, r)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return operations.OperationResponse(op)
+}
+
 func autoSyncImagesTask(s *state.State) (task.Func, task.Schedule) {
 	f := func(ctx context.Context) {
 		// In order to only have one task operation executed per image when syncing the images
@@ -156,6 +156,32 @@ static void forkdonetdhcp(char *logfilestr) {
 	// Jump back to Go for the rest
 }
 
+static void forkdonetstatic(void) {
+	char *pidstr;
+	char path[PATH_MAX];
+
+	// Check that we're root
+	if (geteuid() != 0) {
+		fprintf(stderr, "Error: forknet requires root privileges\n");
+		_exit(1);
+	}
+
+	pidstr = getenv("LXC_PID");
+	if (!pidstr) {
+		fprintf(stderr, "No LXC_PID in environment\n");
+		_exit(1);
+	}
+
+	// Attach to the network namespace.
+	snprintf(path, sizeof(path), "/proc/%s/ns/net", pidstr);
+	if (dosetns_file(path, "net") < 0) {
+		fprintf(stderr, "Failed setns to container network namespace: %s\n", strerror(errno));
+		_exit(1);
+	}
+
+	// Jump back to Go for the rest
+}
+
 void forknet(void)
 {
 	char *command = NULL;
@@ -176,6 +202,11 @@ void forknet(void)
 		return;
 	}
 
+	if (strcmp(command, "static") == 0) {
+		forkdonetstatic();
+		return;
+	}
+
 	// skip "--"
 	advance_arg(true);
 
@@ -192,7 +223,7 @@ void forknet(void)
 	}
 
 	// Call the subcommands
-	if (strcmp(command, "info") == 0) {
+	if (strcmp(command, "info") == 0 || strcmp(command, "linkinfo") == 0) {
 		int ns_fd, pidfd;
 		pid = atoi(cur);
 
@@ -212,6 +243,7 @@ import "C"
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -220,6 +252,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -235,19 +268,56 @@ import (
 	"github.com/lxc/incus/v6/internal/netutils"
 	"github.com/lxc/incus/v6/internal/server/ip"
 	_ "github.com/lxc/incus/v6/shared/cgo" // Used by cgo
+	"github.com/lxc/incus/v6/shared/revert"
 	"github.com/lxc/incus/v6/shared/subprocess"
 	"github.com/lxc/incus/v6/shared/util"
 )
 
+// forknetDHCPRenewAttempts is the number of DHCP renewal attempts before falling back to a full request.
+const forknetDHCPRenewAttempts = 5
+
+// forknetDHCPRenewBackoff is the initial delay between DHCP renewal attempts, doubled after each failure.
+const forknetDHCPRenewBackoff = 2 * time.Second
+
 type cmdForknet struct {
 	global *cmdGlobal
 
+	flagClientID string
+	flagHwaddr   string
+
 	applyDNSMu      sync.Mutex
 	dhcpv4Lease     *nclient4.Lease
 	dhcpv6Lease     *dhcpv6.Message
+	dhcpClientID    []byte
+	dhcpHwaddr      net.HardwareAddr
 	instNetworkPath string
 }
 
+// forknetLinkInfo represents the link-level attributes of an interface as reported by "forknet linkinfo".
+type forknetLinkInfo struct {
+	MTU       int    `json:"mtu"`
+	Hwaddr    string `json:"hwaddr"`
+	State     string `json:"state"`
+	OperState string `json:"oper_state"`
+}
+
+// forknetStaticConfig represents the static network configuration applied by "forknet static".
+type forknetStaticConfig struct {
+	Interface   string               `json:"interface"`
+	Addresses   []string             `json:"addresses"`
+	Routes      []forknetStaticRoute `json:"routes"`
+	Nameservers []string             `json:"nameservers"`
+	Domain      string               `json:"domain"`
+	Search      []string             `json:"search"`
+}
+
+// forknetStaticRoute represents a route in the static network configuration.
+// An empty destination means the default route.
+type forknetStaticRoute struct {
+	Destination string `json:"destination"`
+	Via         string `json:"via"`
+}
+
 func (c *cmdForknet) command() *cobra.Command {
 	// Main subcommand
 	cmd := &cobra.Command{}
@@ -268,6 +338,13 @@ func (c *cmdForknet) command() *cobra.Command {
 	cmdInfo.RunE = c.runInfo
 	cmd.AddCommand(cmdInfo)
 
+	// linkinfo
+	cmdLinkInfo := &cobra.Command{}
+	cmdLinkInfo.Use = "linkinfo <PID> <PidFd>"
+	cmdLinkInfo.Args = cobra.ExactArgs(2)
+	cmdLinkInfo.RunE = c.runLinkInfo
+	cmd.AddCommand(cmdLinkInfo)
+
 	// detach
 	cmdDetach := &cobra.Command{}
 	cmdDetach.Use = "detach <netns file> <daemon PID> <ifname> <hostname>"
@@ -280,8 +357,17 @@ func (c *cmdForknet) command() *cobra.Command {
 	cmdDHCP.Use = "dhcp <path> <logfile>"
 	cmdDHCP.Args = cobra.ExactArgs(2)
 	cmdDHCP.RunE = c.runDHCP
+	cmdDHCP.Flags().StringVar(&c.flagClientID, "client-id", "", "DHCPv4 client identifier (option 61) as colon separated hex bytes, starting with the type"+"``")
+	cmdDHCP.Flags().StringVar(&c.flagHwaddr, "hwaddr", "", "MAC address to use for the DHCPv4 requests instead of the interface's"+"``")
 	cmd.AddCommand(cmdDHCP)
 
+	// static
+	cmdStatic := &cobra.Command{}
+	cmdStatic.Use = "static <config-path>"
+	cmdStatic.Args = cobra.ExactArgs(1)
+	cmdStatic.RunE = c.runStatic
+	cmd.AddCommand(cmdStatic)
+
 	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
 	cmd.Args = cobra.NoArgs
 	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
@@ -305,6 +391,43 @@ func (c *cmdForknet) runInfo(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+// runLinkInfo reports the MTU, MAC address, administrative and operational state of all interfaces.
+func (c *cmdForknet) runLinkInfo(_ *cobra.Command, _ []string) error {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return err
+	}
+
+	links := make(map[string]forknetLinkInfo, len(ifaces))
+	for _, iface := range ifaces {
+		link := forknetLinkInfo{
+			MTU:       iface.MTU,
+			Hwaddr:    iface.HardwareAddr.String(),
+			State:     "down",
+			OperState: "down",
+		}
+
+		if iface.Flags&net.FlagUp != 0 {
+			link.State = "up"
+		}
+
+		if iface.Flags&net.FlagRunning != 0 {
+			link.OperState = "up"
+		}
+
+		links[iface.Name] = link
+	}
+
+	buf, err := json.Marshal(links)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", buf)
+
+	return nil
+}
+
 // RunDHCP spawns the DHCP client(s) and applies address, route and DNS configuration.
 func (c *cmdForknet) runDHCP(_ *cobra.Command, args []string) error {
 	logger := logrus.New()
@@ -320,6 +443,28 @@ func (c *cmdForknet) runDHCP(_ *cobra.Command, args []string) error {
 
 	iface := "eth0"
 
+	// Parse the client identity overrides.
+	if c.flagClientID != "" {
+		clientID, err := forknetParseClientID(c.flagClientID)
+		if err != nil {
+			logger.WithError(err).Error("Giving up on DHCP, invalid client identifier")
+			return err
+		}
+
+		c.dhcpClientID = clientID
+	}
+
+	if c.flagHwaddr != "" {
+		hwaddr, err := net.ParseMAC(c.flagHwaddr)
+		if err != nil || len(hwaddr) != 6 {
+			err = fmt.Errorf("Invalid MAC address %q", c.flagHwaddr)
+			logger.WithError(err).Error("Giving up on DHCP, invalid MAC address")
+			return err
+		}
+
+		c.dhcpHwaddr = hwaddr
+	}
+
 	logger.WithField("interface", iface).Info("running dhcp")
 
 	// Bring the interface up.
@@ -371,7 +516,12 @@ func (c *cmdForknet) runDHCP(_ *cobra.Command, args []string) error {
 
 func (c *cmdForknet) dhcpRunV4(errorChannel chan error, iface string, hostname string, logger *logrus.Logger) {
 	// Try to get a lease.
-	client, err := nclient4.New(iface)
+	var clientOpts []nclient4.ClientOpt
+	if c.dhcpHwaddr != nil {
+		clientOpts = append(clientOpts, nclient4.WithHWAddr(c.dhcpHwaddr))
+	}
+
+	client, err := nclient4.New(iface, clientOpts...)
 	if err != nil {
 		logger.WithError(err).Error("Giving up on DHCPv4, couldn't set up client")
 		errorChannel <- err
@@ -380,19 +530,7 @@ func (c *cmdForknet) dhcpRunV4(errorChannel chan error, iface string, hostname s
 
 	defer func() { _ = client.Close() }()
 
-	lease, err := client.Request(context.Background(),
-		dhcpv4.WithoutOption(dhcpv4.OptionIPAddressLeaseTime),
-		dhcpv4.WithRequestedOptions(
-			dhcpv4.OptionSubnetMask,           // 1
-			dhcpv4.OptionRouter,               // 3
-			dhcpv4.OptionDomainNameServer,     // 6
-			dhcpv4.OptionDomainName,           // 15
-			dhcpv4.OptionClasslessStaticRoute, // 121 (if present)
-			dhcpv4.OptionIPAddressLeaseTime,   // 51
-			dhcpv4.OptionRenewTimeValue,       // 58 (T1)
-			dhcpv4.OptionRebindingTimeValue,   // 59 (T2)
-		),
-		dhcpv4.WithOption(dhcpv4.OptHostName(hostname)))
+	lease, err := c.dhcpRequestV4(client, hostname)
 	if err != nil {
 		logger.WithError(err).WithField("hostname", hostname).
 			Error("Giving up on DHCPv4, couldn't get a lease")
@@ -511,21 +649,177 @@ func (c *cmdForknet) dhcpRunV4(errorChannel chan error, iface string, hostname s
 		time.Sleep(t1)
 
 		// Renew the lease.
-		newLease, err := client.Renew(context.Background(), lease,
+		newLease, err := c.dhcpRenewV4(client, lease, hostname, logger)
+		if err != nil {
+			logger.WithError(err).Error("Giving up on DHCPv4, couldn't renew the lease")
+			errorChannel <- err
+			return
+		}
+
+		// A full request may have handed out a different address.
+		if newLease.Offer != nil && newLease.Offer.YourIPAddr != nil && !newLease.Offer.YourIPAddr.Equal(lease.Offer.YourIPAddr) {
+			logger.WithField("old", lease.Offer.YourIPAddr).WithField("new", newLease.Offer.YourIPAddr).Info("DHCPv4 address changed")
+
+			oldAddr := &ip.Addr{
+				DevName: iface,
+				Address: &net.IPNet{
+					IP:   lease.Offer.YourIPAddr,
+					Mask: lease.Offer.SubnetMask(),
+				},
+				Family: ip.FamilyV4,
+			}
+
+			err = oldAddr.Delete()
+			if err != nil {
+				logger.WithError(err).Warn("Couldn't remove previous DHCPv4 address")
+			}
+
+			newAddr := &ip.Addr{
+				DevName: iface,
+				Address: &net.IPNet{
+					IP:   newLease.Offer.YourIPAddr,
+					Mask: newLease.Offer.SubnetMask(),
+				},
+				Family: ip.FamilyV4,
+			}
+
+			err = newAddr.Add()
+			if err != nil {
+				logger.WithError(err).Error("Giving up on DHCPv4, couldn't add IP")
+				errorChannel <- err
+				return
+			}
+		}
+
+		lease = newLease
+	}
+}
+
+// dhcpModifiersV4 returns the modifiers applied to all DHCPv4 messages, identifying the client.
+func (c *cmdForknet) dhcpModifiersV4(hostname string, modifiers ...dhcpv4.Modifier) []dhcpv4.Modifier {
+	modifiers = append(modifiers, dhcpv4.WithOption(dhcpv4.OptHostName(hostname)))
+
+	if c.dhcpClientID != nil {
+		modifiers = append(modifiers, dhcpv4.WithOption(dhcpv4.OptClientIdentifier(c.dhcpClientID)))
+	}
+
+	return modifiers
+}
+
+// dhcpRequestV4 requests a new DHCPv4 lease.
+func (c *cmdForknet) dhcpRequestV4(client *nclient4.Client, hostname string) (*nclient4.Lease, error) {
+	return client.Request(context.Background(), c.dhcpModifiersV4(hostname,
+		dhcpv4.WithoutOption(dhcpv4.OptionIPAddressLeaseTime),
+		dhcpv4.WithRequestedOptions(
+			dhcpv4.OptionSubnetMask,           // 1
+			dhcpv4.OptionRouter,               // 3
+			dhcpv4.OptionDomainNameServer,     // 6
+			dhcpv4.OptionDomainName,           // 15
+			dhcpv4.OptionClasslessStaticRoute, // 121 (if present)
+			dhcpv4.OptionIPAddressLeaseTime,   // 51
+			dhcpv4.OptionRenewTimeValue,       // 58 (T1)
+			dhcpv4.OptionRebindingTimeValue,   // 59 (T2)
+		),
+	)...)
+}
+
+// dhcpRenewV4 renews the DHCPv4 lease, retrying with an exponential backoff on failure.
+// Once the retries are exhausted, or the lease is about to expire, a full request is made instead.
+func (c *cmdForknet) dhcpRenewV4(client *nclient4.Client, lease *nclient4.Lease, hostname string, logger *logrus.Logger) (*nclient4.Lease, error) {
+	expiry := dhcpLeaseExpiryV4(lease)
+	backoff := forknetDHCPRenewBackoff
+
+	for attempt := 1; attempt <= forknetDHCPRenewAttempts; attempt++ {
+		newLease, err := client.Renew(context.Background(), lease, c.dhcpModifiersV4(hostname,
 			dhcpv4.WithRequestedOptions(
 				dhcpv4.OptionIPAddressLeaseTime, // 51
 				dhcpv4.OptionRenewTimeValue,     // 58
 				dhcpv4.OptionRebindingTimeValue, // 59
 			),
-			dhcpv4.WithOption(dhcpv4.OptHostName(hostname)))
+		)...)
+		if err == nil {
+			if attempt > 1 {
+				logger.WithField("attempt", attempt).Info("DHCPv4 lease renewed")
+			}
+
+			return newLease, nil
+		}
+
+		logger.WithError(err).WithField("attempt", attempt).Warn("Couldn't renew the DHCPv4 lease")
+
+		if attempt == forknetDHCPRenewAttempts {
+			break
+		}
+
+		if !expiry.IsZero() && time.Until(expiry) <= backoff {
+			logger.WithField("expiry", expiry).Warn("DHCPv4 lease about to expire, stopping renewal attempts")
+			break
+		}
+
+		logger.WithField("delay", backoff).Debug("Retrying DHCPv4 renewal")
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+
+	logger.Info("Falling back to a full DHCPv4 request")
+
+	newLease, err := c.dhcpRequestV4(client, hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	if newLease.Offer == nil || newLease.Offer.YourIPAddr == nil || newLease.Offer.SubnetMask() == nil {
+		return nil, errors.New("DHCPv4 lease didn't contain required fields")
+	}
+
+	logger.WithField("address", newLease.Offer.YourIPAddr).Info("Obtained a new DHCPv4 lease")
+
+	return newLease, nil
+}
+
+// forknetParseClientID parses a DHCPv4 client identifier given as colon separated hex bytes.
+// The first byte is the identifier type (e.g. 01 for a MAC address, ff for RFC4361) and at least
+// one byte of identifier must follow it.
+func forknetParseClientID(value string) ([]byte, error) {
+	fields := strings.Split(value, ":")
+	if len(fields) < 2 || len(fields) > 255 {
+		return nil, fmt.Errorf("Client identifier %q must be between 2 and 255 bytes", value)
+	}
+
+	clientID := make([]byte, 0, len(fields))
+	for _, field := range fields {
+		if len(field) != 2 {
+			return nil, fmt.Errorf("Invalid byte %q in client identifier %q", field, value)
+		}
+
+		b, err := hex.DecodeString(field)
 		if err != nil {
-			logger.WithError(err).Error("Giving up on DHCPv4, couldn't renew the lease")
-			errorChannel <- err
-			return
+			return nil, fmt.Errorf("Invalid byte %q in client identifier %q", field, value)
 		}
 
-		lease = newLease
+		clientID = append(clientID, b[0])
 	}
+
+	return clientID, nil
+}
+
+// dhcpLeaseExpiryV4 returns when the DHCPv4 lease expires, or the zero time if its lease time is unknown.
+func dhcpLeaseExpiryV4(lease *nclient4.Lease) time.Time {
+	var leaseTime time.Duration
+
+	if lease.ACK != nil {
+		leaseTime = lease.ACK.IPAddressLeaseTime(0)
+	}
+
+	if leaseTime == 0 && lease.Offer != nil {
+		leaseTime = lease.Offer.IPAddressLeaseTime(0)
+	}
+
+	if leaseTime == 0 {
+		return time.Time{}
+	}
+
+	return lease.CreationTime.Add(leaseTime)
 }
 
 func (c *cmdForknet) dhcpRunV6(errorChannel chan error, iface string, hostname string, logger *logrus.Logger) {
@@ -748,6 +1042,171 @@ func (c *cmdForknet) dhcpApplyDNS(logger *logrus.Logger) error {
 	return nil
 }
 
+// runStatic applies the address, route and DNS configuration from a JSON file.
+// Nothing is applied unless the whole configuration is valid and changes are reverted on failure.
+func (c *cmdForknet) runStatic(_ *cobra.Command, args []string) error {
+	configPath := args[0]
+	c.instNetworkPath = filepath.Dir(configPath)
+
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("Failed reading static network config: %w", err)
+	}
+
+	config := forknetStaticConfig{}
+	err = json.Unmarshal(content, &config)
+	if err != nil {
+		return fmt.Errorf("Failed parsing static network config: %w", err)
+	}
+
+	if config.Interface == "" {
+		config.Interface = "eth0"
+	}
+
+	// Validate the whole configuration before touching the interface.
+	addrs := make([]*ip.Addr, 0, len(config.Addresses))
+	for _, address := range config.Addresses {
+		ipAddr, ipNet, err := net.ParseCIDR(address)
+		if err != nil {
+			return fmt.Errorf("Invalid address %q: %w", address, err)
+		}
+
+		ipNet.IP = ipAddr
+		addrs = append(addrs, &ip.Addr{
+			DevName: config.Interface,
+			Address: ipNet,
+			Family:  forknetIPFamily(ipAddr),
+		})
+	}
+
+	routes := make([]*ip.Route, 0, len(config.Routes))
+	for _, staticRoute := range config.Routes {
+		route := &ip.Route{
+			DevName: config.Interface,
+		}
+
+		if staticRoute.Via != "" {
+			route.Via = net.ParseIP(staticRoute.Via)
+			if route.Via == nil {
+				return fmt.Errorf("Invalid route gateway %q", staticRoute.Via)
+			}
+
+			route.Family = forknetIPFamily(route.Via)
+		}
+
+		if staticRoute.Destination != "" {
+			_, dest, err := net.ParseCIDR(staticRoute.Destination)
+			if err != nil {
+				return fmt.Errorf("Invalid route destination %q: %w", staticRoute.Destination, err)
+			}
+
+			if route.Via != nil && forknetIPFamily(dest.IP) != route.Family {
+				return fmt.Errorf("Route destination %q and gateway %q use different address families", staticRoute.Destination, staticRoute.Via)
+			}
+
+			route.Route = dest
+			route.Family = forknetIPFamily(dest.IP)
+		} else if route.Via == nil {
+			return errors.New("Default route requires a gateway")
+		}
+
+		routes = append(routes, route)
+	}
+
+	for _, nameserver := range config.Nameservers {
+		if net.ParseIP(nameserver) == nil {
+			return fmt.Errorf("Invalid nameserver %q", nameserver)
+		}
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	// Bring the interface up.
+	link := &ip.Link{
+		Name: config.Interface,
+	}
+
+	err = link.SetUp()
+	if err != nil {
+		return fmt.Errorf("Failed bringing up interface %q: %w", config.Interface, err)
+	}
+
+	// Network configuration.
+	for _, addr := range addrs {
+		err = addr.Add()
+		if err != nil {
+			return err
+		}
+
+		reverter.Add(func() { _ = addr.Delete() })
+	}
+
+	for _, route := range routes {
+		err = route.Add()
+		if err != nil {
+			return err
+		}
+
+		reverter.Add(func() { _ = route.Delete() })
+	}
+
+	// DNS configuration.
+	err = c.staticApplyDNS(config)
+	if err != nil {
+		return err
+	}
+
+	reverter.Success()
+
+	return nil
+}
+
+// staticApplyDNS writes resolv.conf next to the static network config, leaving it untouched if no nameservers are set.
+func (c *cmdForknet) staticApplyDNS(config forknetStaticConfig) error {
+	if len(config.Nameservers) == 0 {
+		return nil
+	}
+
+	var sb strings.Builder
+
+	for _, nameserver := range config.Nameservers {
+		fmt.Fprintf(&sb, "nameserver %s\n", nameserver)
+	}
+
+	if config.Domain != "" {
+		fmt.Fprintf(&sb, "domain %s\n", config.Domain)
+	}
+
+	if len(config.Search) > 0 {
+		fmt.Fprintf(&sb, "search %s\n", strings.Join(config.Search, ", "))
+	}
+
+	// Write to a temporary file first so resolv.conf is replaced atomically.
+	path := filepath.Join(c.instNetworkPath, "resolv.conf")
+	err := os.WriteFile(path+".tmp", []byte(sb.String()), 0o644)
+	if err != nil {
+		return fmt.Errorf("Failed writing resolv.conf: %w", err)
+	}
+
+	err = os.Rename(path+".tmp", path)
+	if err != nil {
+		_ = os.Remove(path + ".tmp")
+		return fmt.Errorf("Failed writing resolv.conf: %w", err)
+	}
+
+	return nil
+}
+
+// forknetIPFamily returns the address family of the given IP.
+func forknetIPFamily(addr net.IP) ip.Family {
+	if addr.To4() != nil {
+		return ip.FamilyV4
+	}
+
+	return ip.FamilyV6
+}
+
 func (c *cmdForknet) runDetach(_ *cobra.Command, args []string) error {
 	daemonPID := args[1]
 	ifName := args[2]
@@ -791,14 +1250,17 @@ func (c *cmdForknet) runDetach(_ *cobra.Command, args []string) error {
 	// Rename it back to the host name.
 	err = link.SetName(hostName)
 	if err != nil {
-		// If the interface has an altname that matches the target name, this can prevent rename of the
-		// interface, so try removing it and trying the rename again if succeeds.
-		_, altErr := subprocess.RunCommand("ip", "link", "property", "del", "dev", ifName, "altname", hostName)
-		if altErr == nil {
-			err = link.SetName(hostName)
+		// If any interface has an altname that matches the target name, this can prevent rename of the
+		// interface, so try removing those and trying the rename again.
+		altErr := c.removeConflictingAltNames(hostName)
+		if altErr != nil {
+			return fmt.Errorf("Failed renaming interface %q to %q: %w (%w)", ifName, hostName, err, altErr)
 		}
 
-		return err
+		err = link.SetName(hostName)
+		if err != nil {
+			return fmt.Errorf("Failed renaming interface %q to %q: %w", ifName, hostName, err)
+		}
 	}
 
 	// Move it back to the host.
@@ -826,3 +1288,34 @@ func (c *cmdForknet) runDetach(_ *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// removeConflictingAltNames removes the given name from the alternative names of all interfaces.
+func (c *cmdForknet) removeConflictingAltNames(name string) error {
+	out, err := subprocess.RunCommand("ip", "-j", "link", "show")
+	if err != nil {
+		return err
+	}
+
+	links := []struct {
+		Name     string   `json:"ifname"`
+		AltNames []string `json:"altnames"`
+	}{}
+
+	err = json.Unmarshal([]byte(out), &links)
+	if err != nil {
+		return fmt.Errorf("Failed parsing interface list: %w", err)
+	}
+
+	for _, link := range links {
+		if !slices.Contains(link.AltNames, name) {
+			continue
+		}
+
+		_, err := subprocess.RunCommand("ip", "link", "property", "del", "dev", link.Name, "altname", name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -649,6 +649,42 @@ func networkLoadBalancerPut(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	var loadBalancer *api.NetworkLoadBalancer
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		networkID := n.ID()
+
+		// Get the load balancer.
+		dbLoadBalancers, err := dbCluster.GetNetworkLoadBalancers(ctx, tx.Tx(), dbCluster.NetworkLoadBalancerFilter{
+			NetworkID:     &networkID,
+			ListenAddress: &listenAddress,
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(dbLoadBalancers) != 1 {
+			return api.StatusErrorf(http.StatusNotFound, "Network load balancer not found")
+		}
+
+		// Get the API struct.
+		loadBalancer, err = dbLoadBalancers[0].ToAPI(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Validate the ETag.
+	err = localUtil.EtagCheck(r, loadBalancer.Etag())
+	if err != nil {
+		return response.PreconditionFailed(err)
+	}
+
 	// Decode the request.
 	req := api.NetworkLoadBalancerPut{}
 	err = json.NewDecoder(r.Body).Decode(&req)
@@ -657,36 +693,6 @@ func networkLoadBalancerPut(d *Daemon, r *http.Request) response.Response {
 	}
 
 	if r.Method == http.MethodPatch {
-		var loadBalancer *api.NetworkLoadBalancer
-
-		err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
-			networkID := n.ID()
-
-			// Get the load balancer.
-			dbLoadBalancers, err := dbCluster.GetNetworkLoadBalancers(ctx, tx.Tx(), dbCluster.NetworkLoadBalancerFilter{
-				NetworkID:     &networkID,
-				ListenAddress: &listenAddress,
-			})
-			if err != nil {
-				return err
-			}
-
-			if len(dbLoadBalancers) != 1 {
-				return api.StatusErrorf(http.StatusNotFound, "Network load balancer not found")
-			}
-
-			// Get the API struct.
-			loadBalancer, err = dbLoadBalancers[0].ToAPI(ctx, tx.Tx())
-			if err != nil {
-				return err
-			}
-
-			return nil
-		})
-		if err != nil {
-			return response.SmartError(err)
-		}
-
 		// If config being updated via "patch" method, then merge all existing config with the keys that
 		// are present in the request config.
 		for k, v := range loadBalancer.Config {
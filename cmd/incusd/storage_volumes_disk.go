@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/gorilla/mux"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/server/auth"
+	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/db/operationtype"
+	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/project"
+	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/response"
+	storagePools "github.com/lxc/incus/v6/internal/server/storage"
+	storageDrivers "github.com/lxc/incus/v6/internal/server/storage/drivers"
+	internalUtil "github.com/lxc/incus/v6/internal/util"
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+var storagePoolVolumeTypeDiskCmd = APIEndpoint{
+	Path: "storage-pools/{poolName}/volumes/{type}/{volumeName}/disk",
+
+	Get: APIEndpointAction{Handler: storagePoolVolumeTypeDiskGet, AccessHandler: allowPermission(auth.ObjectTypeStorageVolume, auth.EntitlementCanManageBackups, "poolName", "type", "volumeName", "location")},
+}
+
+// swagger:operation GET /1.0/storage-pools/{poolName}/volumes/{type}/{volumeName}/disk storage storage_pool_volume_type_disk_get
+//
+//	Get the storage volume as a disk image
+//
+//	Converts a custom or virtual machine block volume (or one of its snapshots) to a qcow2 disk image and downloads it.
+//	Volumes which aren't snapshots are converted from a temporary snapshot.
+//	The conversion runs as a background operation before the download starts.
+//
+//	---
+//	produces:
+//	  - application/octet-stream
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	  - in: query
+//	    name: snapshot
+//	    description: Name of the snapshot to export instead of the volume
+//	    type: string
+//	    example: snap0
+//	  - in: query
+//	    name: format
+//	    description: Disk image format (only qcow2 is supported)
+//	    type: string
+//	    example: qcow2
+//	responses:
+//	  "200":
+//	    description: Disk image data
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func storagePoolVolumeTypeDiskGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// Get the name of the storage pool the volume is supposed to be attached to.
+	poolName, err := url.PathUnescape(mux.Vars(r)["poolName"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Get the volume type.
+	volumeTypeName, err := url.PathUnescape(mux.Vars(r)["type"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Get the name of the storage volume.
+	volumeName, err := url.PathUnescape(mux.Vars(r)["volumeName"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Convert the volume type name to our internal integer representation.
+	volumeType, err := storagePools.VolumeTypeNameToDBType(volumeTypeName)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	// Check that the storage volume type is valid.
+	if !slices.Contains([]int{db.StoragePoolVolumeTypeCustom, db.StoragePoolVolumeTypeVM}, volumeType) {
+		return response.BadRequest(fmt.Errorf("Invalid storage volume type %q", volumeTypeName))
+	}
+
+	volType, err := storagePools.VolumeDBTypeToType(volumeType)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	format := request.QueryParam(r, "format")
+	if format != "" && format != "qcow2" {
+		return response.BadRequest(fmt.Errorf("Unsupported disk image format %q", format))
+	}
+
+	projectName, err := project.StorageVolumeProject(s.DB.Cluster, request.ProjectParam(r), volumeType)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	if volumeType == db.StoragePoolVolumeTypeCustom {
+		resp = forwardedResponseIfVolumeIsRemote(s, r, poolName, projectName, volumeName, volumeType)
+		if resp != nil {
+			return resp
+		}
+	} else {
+		resp, err := forwardedResponseIfInstanceIsRemote(s, r, projectName, volumeName)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		if resp != nil {
+			return resp
+		}
+	}
+
+	fullName := volumeName
+	fileName := volumeName
+	snapshotName := request.QueryParam(r, "snapshot")
+	if snapshotName != "" {
+		fullName = volumeName + internalInstance.SnapshotDelimiter + snapshotName
+		fileName = volumeName + "-" + snapshotName
+	}
+
+	pool, err := storagePools.LoadByName(s, poolName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	tmpPath, err := os.MkdirTemp(internalUtil.VarPath("backups"), "incus_disk_export_")
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	cleanup := func() { _ = os.RemoveAll(tmpPath) }
+
+	imgPath := filepath.Join(tmpPath, "disk.qcow2")
+	export := func(op *operations.Operation) error {
+		return pool.ExportVolumeDisk(projectName, volType, fullName, imgPath, op)
+	}
+
+	resources := map[string][]api.URL{}
+	resources["storage_volumes"] = []api.URL{*api.NewURL().Path(version.APIVersion, "storage-pools", poolName, "volumes", volumeTypeName, volumeName)}
+
+	op, err := operations.OperationCreate(s, request.ProjectParam(r), operations.OperationClassTask, operationtype.VolumeDiskExport, resources, nil, export, nil, nil, r)
+	if err != nil {
+		cleanup()
+		return response.InternalError(err)
+	}
+
+	err = op.Start()
+	if err != nil {
+		cleanup()
+		return response.InternalError(err)
+	}
+
+	err = op.Wait(r.Context())
+	if err != nil {
+		if r.Context().Err() != nil {
+			// The client went away, clean up once the conversion is done.
+			go func() {
+				_ = op.Wait(context.Background())
+				cleanup()
+			}()
+
+			return response.SmartError(err)
+		}
+
+		cleanup()
+
+		if errors.Is(err, storageDrivers.ErrNotSupported) {
+			return response.BadRequest(err)
+		}
+
+		return response.SmartError(err)
+	}
+
+	ent := response.FileResponseEntry{
+		Path:     imgPath,
+		Filename: fmt.Sprintf("%s.qcow2", fileName),
+		Cleanup:  cleanup,
+	}
+
+	return response.FileResponse(r, []response.FileResponseEntry{ent}, nil)
+}
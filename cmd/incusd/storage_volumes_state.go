@@ -162,5 +162,16 @@ func storagePoolVolumeTypeStateGet(d *Daemon, r *http.Request) response.Response
 		}
 	}
 
+	// Fetch the driver specific details.
+	volType, err := storagePools.VolumeDBTypeToType(volumeType)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	state.DriverInfo, err = pool.GetVolumeInfo(projectName, volType, volumeName)
+	if err != nil && !errors.Is(err, storageDrivers.ErrNotSupported) {
+		return response.SmartError(err)
+	}
+
 	return response.SyncResponse(true, state)
 }
@@ -8,6 +8,8 @@ import (
 )
 
 // Clause is a single filter clause in a filter string.
+// A clause without operator is a predicate: the field must hold a true value, or when the field
+// ends with "?", the field must exist.
 type Clause struct {
 	PrevLogical string
 	Not         bool
@@ -59,8 +61,25 @@ func Parse(s string, op OperatorSet) (*ClauseSet, error) {
 		clause.Field = parts[index]
 
 		index++
-		if index == len(parts) {
-			return nil, errors.New("clause has no operator")
+
+		// A field on its own is a predicate clause.
+		if index == len(parts) || slices.Contains([]string{op.And, op.Or}, parts[index]) {
+			if strings.TrimSuffix(clause.Field, "?") == "" {
+				return nil, errors.New("clause has no field")
+			}
+
+			clause.PrevLogical = prevLogical
+			if index < len(parts) {
+				prevLogical = parts[index]
+
+				index++
+				if index == len(parts) {
+					return nil, errors.New("unterminated compound clause")
+				}
+			}
+
+			clauses = append(clauses, clause)
+			continue
 		}
 
 		clause.Operator = parts[index]
@@ -12,8 +12,9 @@ import (
 func TestParse_Error(t *testing.T) {
 	cases := map[string]string{
 		"not":                    "incomplete not clause",
-		"foo":                    "clause has no operator",
-		"not foo":                "clause has no operator",
+		"foo and":                "unterminated compound clause",
+		"not foo or":             "unterminated compound clause",
+		"? and foo":              "clause has no field",
 		"foo eq":                 "clause has no value",
 		"foo eq \"bar":           "unterminated quote",
 		"foo eq bar and":         "unterminated compound clause",
@@ -41,10 +41,24 @@ func Match(obj any, set ClauseSet) (bool, error) {
 	match := true
 
 	for _, clause := range set.Clauses {
-		value := ValueOf(obj, clause.Field)
-		clauseMatch, err := set.match(clause, value)
-		if err != nil {
-			return false, err
+		var clauseMatch bool
+
+		if clause.Operator == "" {
+			// Predicate clause.
+			field, exists := strings.CutSuffix(clause.Field, "?")
+			if exists {
+				clauseMatch = Exists(obj, field)
+			} else {
+				clauseMatch = IsTrue(obj, field)
+			}
+		} else {
+			var err error
+
+			value := ValueOf(obj, clause.Field)
+			clauseMatch, err = set.match(clause, value)
+			if err != nil {
+				return false, err
+			}
 		}
 
 		// Finish out logic
@@ -57,6 +57,48 @@ func TestMatch_Instance(t *testing.T) {
 	}
 }
 
+func TestMatch_Predicates(t *testing.T) {
+	instance := api.Instance{
+		InstancePut: api.InstancePut{
+			Config: map[string]string{
+				"user.foo":         "yes",
+				"security.nesting": "false",
+			},
+			Ephemeral: true,
+			Stateful:  false,
+		},
+		Name: "c1",
+	}
+
+	cases := map[string]any{
+		"ephemeral":                                  true,
+		"stateful":                                   false,
+		"not stateful":                               true,
+		"config.user.foo":                            true,
+		"config.user.foo?":                           true,
+		"config.user.bar?":                           false,
+		"not config.user.bar?":                       true,
+		"config.security.nesting":                    false,
+		"config.security.nesting?":                   true,
+		"ephemeral and name eq c1":                   true,
+		"name eq c2 or config.user.foo?":             true,
+		"config.user.bar? or stateful":               false,
+		"expanded_devices.root.pool?":                false,
+		"name":                                       false,
+		"ephemeral and config.user.foo? or stateful": true,
+	}
+
+	for s := range cases {
+		t.Run(s, func(t *testing.T) {
+			f, err := filter.Parse(s, filter.QueryOperatorSet())
+			require.NoError(t, err)
+			match, err := filter.Match(instance, *f)
+			require.NoError(t, err)
+			assert.Equal(t, cases[s], match)
+		})
+	}
+}
+
 func TestMatch_Image(t *testing.T) {
 	image := api.Image{
 		ImagePut: api.ImagePut{
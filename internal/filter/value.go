@@ -1,8 +1,13 @@
 package filter
 
 import (
+	"fmt"
+	"maps"
 	"reflect"
+	"slices"
 	"strings"
+
+	"github.com/lxc/incus/v6/shared/util"
 )
 
 // DotPrefixMatch finds the shortest unambiguous identifier for a given namespace.
@@ -23,8 +28,60 @@ func DotPrefixMatch(short string, full string) bool {
 	return true
 }
 
+// DotPrefixMatchUnique returns the candidate key matched by the short identifier (see DotPrefixMatch).
+// An exact match always wins. An empty string is returned when nothing matches and an error when
+// the short identifier is ambiguous and matches several keys.
+func DotPrefixMatchUnique(short string, candidates []string) (string, error) {
+	if slices.Contains(candidates, short) {
+		return short, nil
+	}
+
+	matches := []string{}
+	for _, candidate := range candidates {
+		if DotPrefixMatch(short, candidate) {
+			matches = append(matches, candidate)
+		}
+	}
+
+	if len(matches) > 1 {
+		slices.Sort(matches)
+		return "", fmt.Errorf("Ambiguous field %q matches %s", short, strings.Join(matches, ", "))
+	}
+
+	if len(matches) == 0 {
+		return "", nil
+	}
+
+	return matches[0], nil
+}
+
 // ValueOf returns the value of the given field.
 func ValueOf(obj any, field string) any {
+	v, _ := lookupValue(obj, field)
+	return v
+}
+
+// Exists returns whether the given field is present on the object, for example a configuration key being set.
+func Exists(obj any, field string) bool {
+	_, found := lookupValue(obj, field)
+	return found
+}
+
+// IsTrue returns whether the given field holds a true boolean, or a string (such as a configuration value)
+// representing true.
+func IsTrue(obj any, field string) bool {
+	switch v := ValueOf(obj, field).(type) {
+	case bool:
+		return v
+	case string:
+		return util.IsTrue(v)
+	}
+
+	return false
+}
+
+// lookupValue returns the value of the given field and whether the field was found.
+func lookupValue(obj any, field string) (any, bool) {
 	value := reflect.ValueOf(obj)
 	typ := value.Type()
 	parts := strings.Split(field, ".")
@@ -36,28 +93,34 @@ func ValueOf(obj any, field string) any {
 		switch reflect.TypeOf(obj).Elem().Kind() {
 		case reflect.String:
 			m := value.Interface().(map[string]string)
-			for k, v := range m {
-				if DotPrefixMatch(field, k) {
-					return v
-				}
+
+			// Ambiguous short forms don't match any key.
+			k, err := DotPrefixMatchUnique(field, slices.Collect(maps.Keys(m)))
+			if err != nil || k == "" {
+				return "", false
 			}
 
-			return m[field]
+			return m[k], true
 
 		case reflect.Map:
+			keys := []string{}
 			for _, entry := range value.MapKeys() {
-				if entry.Interface() != key {
-					continue
+				if entry.Kind() == reflect.String {
+					keys = append(keys, entry.String())
 				}
+			}
 
-				m := value.MapIndex(entry)
-				return ValueOf(m.Interface(), rest)
+			// Resolve the intermediate key with the same prefix matching as the leaf keys.
+			k, err := DotPrefixMatchUnique(key, keys)
+			if err != nil || k == "" {
+				return nil, false
 			}
 
-			return nil
+			m := value.MapIndex(reflect.ValueOf(k))
+			return lookupValue(m.Interface(), rest)
 
 		default:
-			return nil
+			return nil, false
 		}
 	}
 
@@ -67,9 +130,9 @@ func ValueOf(obj any, field string) any {
 		yaml := fieldType.Tag.Get("yaml")
 
 		if yaml == ",inline" {
-			v := ValueOf(fieldValue.Interface(), field)
-			if v != nil {
-				return v
+			v, found := lookupValue(fieldValue.Interface(), field)
+			if found {
+				return v, true
 			}
 		}
 
@@ -77,12 +140,12 @@ func ValueOf(obj any, field string) any {
 		if yamlKey == key {
 			v := fieldValue.Interface()
 			if len(parts) == 1 {
-				return v
+				return v, true
 			}
 
-			return ValueOf(v, rest)
+			return lookupValue(v, rest)
 		}
 	}
 
-	return nil
+	return nil, false
 }
@@ -63,3 +63,72 @@ func TestValueOf_Instance(t *testing.T) {
 		})
 	}
 }
+
+func TestDotPrefixMatchUnique(t *testing.T) {
+	candidates := []string{"security.privileged", "security.protection.delete", "security.nesting", "user.blah"}
+
+	cases := map[string]string{
+		"s.privileged":        "security.privileged",
+		"s.n":                 "security.nesting",
+		"u.blah":              "user.blah",
+		"security.nesting":    "security.nesting",
+		"volatile.base_image": "",
+	}
+
+	for short, expected := range cases {
+		t.Run(short, func(t *testing.T) {
+			full, err := filter.DotPrefixMatchUnique(short, candidates)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, full)
+		})
+	}
+
+	_, err := filter.DotPrefixMatchUnique("s.p", []string{"security.privileged", "security.protection"})
+	assert.EqualError(t, err, `Ambiguous field "s.p" matches security.privileged, security.protection`)
+
+	// An ambiguous short form doesn't resolve to an arbitrary key.
+	config := map[string]string{"security.privileged": "true", "security.protection": "false"}
+	assert.Equal(t, "", filter.ValueOf(config, "s.p"))
+	assert.Equal(t, "true", filter.ValueOf(config, "s.pri"))
+}
+
+func TestValueOf_NestedMap(t *testing.T) {
+	devices := map[string]map[string]string{
+		"root": {
+			"path": "/",
+			"pool": "default",
+		},
+		"eth0": {
+			"nictype": "bridged",
+			"parent":  "incusbr0",
+		},
+		"eth1": {
+			"nictype": "macvlan",
+		},
+	}
+
+	cases := map[string]any{
+		"root.pool":    "default",
+		"r.pool":       "default",
+		"r.po":         "default",
+		"eth0.nictype": "bridged",
+		"eth0.n":       "bridged",
+		"eth1.nictype": "macvlan",
+		"eth.nictype":  nil, // Ambiguous between eth0 and eth1.
+		"missing.path": nil,
+		"root.missing": "",
+		"r.p":          "", // Ambiguous between path and pool.
+		"eth0.parent":  "incusbr0",
+		"e.parent":     nil,
+		"eth0.p":       "incusbr0",
+		"root.path":    "/",
+		"ro.path":      "/",
+		"eth1.n":       "macvlan",
+	}
+
+	for field := range cases {
+		t.Run(field, func(t *testing.T) {
+			assert.Equal(t, cases[field], filter.ValueOf(devices, field))
+		})
+	}
+}
@@ -27,6 +27,9 @@ var HugePageSizeKeys = [...]string{"limits.hugepages.64KB", "limits.hugepages.1M
 // HugePageSizeSuffix contains the list of known hugepage size suffixes.
 var HugePageSizeSuffix = [...]string{"64KB", "1MB", "2MB", "1GB"}
 
+// CloudInitStatuses contains the cloud-init statuses an instance can report through the guest API.
+var CloudInitStatuses = []string{"running", "done", "error", "disabled"}
+
 // InstanceConfigKeysAny is a map of config key to validator. (keys applying to containers AND virtual machines).
 var InstanceConfigKeysAny = map[string]func(value string) error{
 	// gendoc:generate(entity=instance, group=boot, key=boot.autorestart)
@@ -287,6 +290,24 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: Whether `/dev/incus` is present in the instance
 	"security.guestapi": validate.Optional(validate.IsBool),
 
+	// gendoc:generate(entity=instance, group=security, key=security.guestapi.devices)
+	//
+	// ---
+	//  type: bool
+	//  defaultdesc: `true`
+	//  liveupdate: yes
+	//  shortdesc: Controls the availability of the `/1.0/devices` API over `guestapi`
+	"security.guestapi.devices": validate.Optional(validate.IsBool),
+
+	// gendoc:generate(entity=instance, group=security, key=security.guestapi.events)
+	//
+	// ---
+	//  type: bool
+	//  defaultdesc: `true`
+	//  liveupdate: yes
+	//  shortdesc: Controls the availability of the `/1.0/events` API over `guestapi`
+	"security.guestapi.events": validate.Optional(validate.IsBool),
+
 	// gendoc:generate(entity=instance, group=security, key=security.protection.delete)
 	//
 	// ---
@@ -369,6 +390,13 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: Hash of the base image
 	"volatile.base_image": validate.IsAny,
 
+	// gendoc:generate(entity=instance, group=volatile, key=volatile.cloud-init.status)
+	// The `cloud-init` status last reported by the instance through the guest API (`running`, `done`, `error` or `disabled`).
+	// ---
+	//  type: string
+	//  shortdesc: `cloud-init` status reported by the instance
+	"volatile.cloud-init.status": validate.Optional(validate.IsOneOf(CloudInitStatuses...)),
+
 	// gendoc:generate(entity=instance, group=volatile, key=volatile.cloud_init.instance-id)
 	//
 	// ---
@@ -21,6 +21,7 @@ type InstanceTarWriter struct {
 	tarWriter *tar.Writer
 	idmapSet  *idmap.Set
 	linkMap   map[uint64]string
+	wrap      func(io.Reader) io.Reader
 }
 
 // NewInstanceTarWriter returns a ContainerTarWriter for the provided target Writer and id map.
@@ -38,6 +39,12 @@ func (ctw *InstanceTarWriter) ResetHardLinkMap() {
 	ctw.linkMap = map[uint64]string{}
 }
 
+// WrapReaders sets a function wrapping the readers of the file contents added to the tarball, for example to
+// throttle them. Passing nil stops wrapping them.
+func (ctw *InstanceTarWriter) WrapReaders(wrap func(io.Reader) io.Reader) {
+	ctw.wrap = wrap
+}
+
 // WriteFile adds a file to the tarball with the specified name using the srcPath file as the contents of the file.
 // The ignoreGrowth argument indicates whether to error if the srcPath file increases in size beyond the size in fi
 // during the write. If false the write will return an error. If true, no error is returned, instead only the size
@@ -172,6 +179,10 @@ func (ctw *InstanceTarWriter) WriteFile(name string, srcPath string, fi os.FileI
 			r = io.LimitReader(r, fi.Size())
 		}
 
+		if ctw.wrap != nil {
+			r = ctw.wrap(r)
+		}
+
 		_, err = io.Copy(ctw.tarWriter, r)
 		if err != nil {
 			return fmt.Errorf("Failed to copy file content %q: %w", srcPath, err)
@@ -199,6 +210,10 @@ func (ctw *InstanceTarWriter) WriteFileFromReader(src io.Reader, fi os.FileInfo)
 		return fmt.Errorf("Failed to write tar header: %w", err)
 	}
 
+	if ctw.wrap != nil {
+		src = ctw.wrap(src)
+	}
+
 	_, err = io.Copy(ctw.tarWriter, src)
 	return err
 }
@@ -79,6 +79,10 @@ const (
 	BucketBackupRemove
 	BucketBackupRename
 	BucketBackupRestore
+	ImageCache
+	VolumeDiskExport
+	StoragePoolAudit
+	StoragePoolReconcile
 )
 
 // Description return a human-readable description of the operation type.
@@ -210,6 +214,14 @@ func (t Type) Description() string {
 		return "Renaming bucket backup"
 	case BucketBackupRestore:
 		return "Restoring bucket backup"
+	case ImageCache:
+		return "Caching image in storage pool"
+	case VolumeDiskExport:
+		return "Exporting storage volume disk image"
+	case StoragePoolAudit:
+		return "Auditing storage pool"
+	case StoragePoolReconcile:
+		return "Reconciling storage pool volumes"
 	default:
 		return "Executing operation"
 	}
@@ -280,6 +292,10 @@ func (t Type) Permission() (auth.ObjectType, auth.Entitlement) {
 		return auth.ObjectTypeImage, auth.EntitlementCanEdit
 	case ImagesSynchronize:
 		return auth.ObjectTypeImage, auth.EntitlementCanEdit
+	case ImageCache:
+		return auth.ObjectTypeImage, auth.EntitlementCanEdit
+	case VolumeDiskExport:
+		return auth.ObjectTypeStorageVolume, auth.EntitlementCanManageBackups
 
 	case CustomVolumeSnapshotsExpire:
 		return auth.ObjectTypeStorageVolume, auth.EntitlementCanEdit
@@ -7,7 +7,9 @@ import (
 	"math"
 	"math/rand/v2"
 	"net/http"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cowsql/go-cowsql/driver"
@@ -19,13 +21,76 @@ import (
 
 const maxRetries = 250
 
+// retryConfig holds the settings of Retry which can be changed through RetryOption.
+type retryConfig struct {
+	jitter float64
+	rand   *rand.Rand
+}
+
+// RetryOption changes the behavior of Retry.
+type RetryOption func(*retryConfig)
+
+// WithRetryJitter sets the deviation factor applied to the delay between attempts (0.8 by default).
+func WithRetryJitter(factor float64) RetryOption {
+	return func(c *retryConfig) {
+		c.jitter = factor
+	}
+}
+
+// WithRetryRand sets the random source used to compute the delay between attempts, making it deterministic.
+func WithRetryRand(r *rand.Rand) RetryOption {
+	return func(c *retryConfig) {
+		c.rand = r
+	}
+}
+
+// Additional retriable errors registered through RegisterRetriableErrors and RegisterRetriableErrorCodes.
+var (
+	retriableErrorMessages []string
+	retriableErrorCodes    []int
+	retriableErrorsMu      sync.RWMutex
+)
+
+// RegisterRetriableErrors registers additional error message substrings which IsRetriableError considers
+// transient, on top of the built-in ones. This is meant to be called at init time by deployments whose
+// database engine reports transient errors with a different wording.
+func RegisterRetriableErrors(substrings ...string) {
+	retriableErrorsMu.Lock()
+	defer retriableErrorsMu.Unlock()
+
+	retriableErrorMessages = append(retriableErrorMessages, substrings...)
+}
+
+// RegisterRetriableErrorCodes registers additional cowsql error codes which IsRetriableError considers
+// transient, on top of the built-in ones.
+func RegisterRetriableErrorCodes(codes ...int) {
+	retriableErrorsMu.Lock()
+	defer retriableErrorsMu.Unlock()
+
+	retriableErrorCodes = append(retriableErrorCodes, codes...)
+}
+
 // Retry wraps a function that interacts with the database, and retries it in
 // case a transient error is hit.
 //
 // This should by typically used to wrap transactions.
-func Retry(ctx context.Context, f func(ctx context.Context) error) error {
+func Retry(ctx context.Context, f func(ctx context.Context) error, options ...RetryOption) error {
+	_, err := RetryN(ctx, f, options...)
+	return err
+}
+
+// RetryN is like Retry but also returns the number of times the function was called, allowing callers
+// to notice contention on the database.
+func RetryN(ctx context.Context, f func(ctx context.Context) error, options ...RetryOption) (int, error) {
+	config := retryConfig{jitter: 0.8}
+	for _, option := range options {
+		option(&config)
+	}
+
 	var err error
+	attempts := 0
 	for i := range maxRetries {
+		attempts++
 		err = f(ctx)
 		if err == nil {
 			// The function succeeded, we're done here.
@@ -54,24 +119,37 @@ func Retry(ctx context.Context, f func(ctx context.Context) error) error {
 		}
 
 		logger.Debug("Database error, retrying", logger.Ctx{"attempt": i, "err": err})
-		time.Sleep(jitterDeviation(0.8, 100*time.Millisecond))
+		time.Sleep(jitterDeviation(config.rand, config.jitter, 100*time.Millisecond))
 	}
 
-	return err
+	return attempts, err
 }
 
-func jitterDeviation(factor float64, duration time.Duration) time.Duration {
+func jitterDeviation(r *rand.Rand, factor float64, duration time.Duration) time.Duration {
 	floor := int64(math.Floor(float64(duration) * (1 - factor)))
 	ceil := int64(math.Ceil(float64(duration) * (1 + factor)))
-	return time.Duration(rand.Int64N(ceil-floor) + floor)
+	if ceil <= floor {
+		return duration
+	}
+
+	if r == nil {
+		return time.Duration(rand.Int64N(ceil-floor) + floor)
+	}
+
+	return time.Duration(r.Int64N(ceil-floor) + floor)
 }
 
 // IsRetriableError returns true if the given error might be transient and the
 // interaction can be safely retried.
+//
+// Additional errors can be registered with RegisterRetriableErrors and RegisterRetriableErrorCodes.
 func IsRetriableError(err error) bool {
 	var dErr *driver.Error
 
-	if errors.As(err, &dErr) && dErr.Code == driver.ErrBusy {
+	retriableErrorsMu.RLock()
+	defer retriableErrorsMu.RUnlock()
+
+	if errors.As(err, &dErr) && (dErr.Code == driver.ErrBusy || slices.Contains(retriableErrorCodes, dErr.Code)) {
 		return true
 	}
 
@@ -96,6 +174,12 @@ func IsRetriableError(err error) bool {
 		if strings.Contains(err.Error(), "checkpoint in progress") {
 			return true
 		}
+
+		for _, message := range retriableErrorMessages {
+			if strings.Contains(err.Error(), message) {
+				return true
+			}
+		}
 	}
 
 	return false
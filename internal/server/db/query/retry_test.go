@@ -0,0 +1,55 @@
+package query_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/cowsql/go-cowsql/driver"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lxc/incus/v6/internal/server/db/query"
+)
+
+func TestIsRetriableError(t *testing.T) {
+	assert.True(t, query.IsRetriableError(errors.New("database is locked")))
+	assert.True(t, query.IsRetriableError(fmt.Errorf("Failed query: %w", errors.New("bad connection"))))
+	assert.True(t, query.IsRetriableError(&driver.Error{Code: driver.ErrBusy}))
+	assert.False(t, query.IsRetriableError(errors.New("leader changed")))
+	assert.False(t, query.IsRetriableError(&driver.Error{Code: 1234}))
+	assert.False(t, query.IsRetriableError(nil))
+
+	query.RegisterRetriableErrors("leader changed")
+	query.RegisterRetriableErrorCodes(1234)
+
+	assert.True(t, query.IsRetriableError(fmt.Errorf("Failed query: %w", errors.New("leader changed"))))
+	assert.True(t, query.IsRetriableError(&driver.Error{Code: 1234}))
+}
+
+func TestRetry(t *testing.T) {
+	calls := 0
+	attempts, err := query.RetryN(context.Background(), func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return errors.New("database is locked")
+		}
+
+		return nil
+	}, query.WithRetryJitter(0), query.WithRetryRand(rand.New(rand.NewPCG(1, 2))))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, 3, attempts)
+
+	// Non retriable errors are returned straight away.
+	calls = 0
+	err = query.Retry(context.Background(), func(ctx context.Context) error {
+		calls++
+		return errors.New("boom")
+	})
+
+	assert.EqualError(t, err, "boom")
+	assert.Equal(t, 1, calls)
+}
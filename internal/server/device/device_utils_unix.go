@@ -96,6 +96,84 @@ func unixDeviceDestPath(m deviceConfig.Device) string {
 	return destPath
 }
 
+// UnixDeviceValidate checks that the supplied device config map describes a UNIX device that can be
+// created, i.e. that at least one of its "source" and "path" properties is set.
+func UnixDeviceValidate(m deviceConfig.Device) error {
+	if m["source"] == "" && m["path"] == "" {
+		return errors.New("Unix device entry is missing the required \"source\" or \"path\" property")
+	}
+
+	return nil
+}
+
+// unixDeviceMirrorDir is the directory under the devices path holding the devices created with a
+// mirrored path. Device names can't start with a full stop, so it can't clash with other device files.
+const unixDeviceMirrorDir = ".unix"
+
+// unixDeviceFiles returns the host side device files in devicesPath, indexed by their encoded file name.
+// Devices created with a mirrored path are returned under the file name they would have had if they
+// had been created with the default encoded file name scheme.
+func unixDeviceFiles(devicesPath string) (map[string]string, error) {
+	devFiles := map[string]string{}
+
+	dents, err := os.ReadDir(devicesPath)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	for _, ent := range dents {
+		if ent.Name() == unixDeviceMirrorDir {
+			continue
+		}
+
+		devFiles[ent.Name()] = filepath.Join(devicesPath, ent.Name())
+	}
+
+	// Only walk the directories created for devices with a mirrored path.
+	mirrorPath := filepath.Join(devicesPath, unixDeviceMirrorDir)
+
+	dents, err = os.ReadDir(mirrorPath)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	for _, ent := range dents {
+		if !ent.IsDir() {
+			continue
+		}
+
+		entPath := filepath.Join(mirrorPath, ent.Name())
+
+		err := filepath.WalkDir(entPath, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if d.IsDir() {
+				return nil
+			}
+
+			relPath, err := filepath.Rel(entPath, path)
+			if err != nil {
+				return err
+			}
+
+			devFiles[deviceJoinPath(ent.Name(), linux.PathNameEncode(relPath))] = path
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return devFiles, nil
+}
+
 // UnixDeviceCreate creates a UNIX device (either block or char). If the supplied device config map
 // contains a major and minor number for the device, then a stat is avoided, otherwise this info
 // retrieved from the origin device. Similarly, if a mode is supplied in the device config map or
@@ -103,9 +181,15 @@ func unixDeviceDestPath(m deviceConfig.Device) string {
 // respectively, otherwise the origin device's mode is used. If the device config doesn't contain a
 // type field then it defaults to created a unix-char device. The ownership of the created device
 // defaults to root (0) but can be specified with the uid and gid fields in the device config map.
+// If mirrorPath is true, the device is created under a directory named after the encoded prefix in
+// unixDeviceMirrorDir, mirroring its path inside the instance, rather than as a single encoded file name.
 // It returns a UnixDevice containing information about the device created.
-func UnixDeviceCreate(s *state.State, idmapSet *idmap.Set, devicesPath string, prefix string, m deviceConfig.Device, defaultMode bool) (*UnixDevice, error) {
-	var err error
+func UnixDeviceCreate(s *state.State, idmapSet *idmap.Set, devicesPath string, prefix string, m deviceConfig.Device, defaultMode bool, mirrorPath bool) (*UnixDevice, error) {
+	err := UnixDeviceValidate(m)
+	if err != nil {
+		return nil, err
+	}
+
 	d := UnixDevice{}
 
 	// Extra checks for nesting.
@@ -203,6 +287,15 @@ func UnixDeviceCreate(s *state.State, idmapSet *idmap.Set, devicesPath string, p
 	devName := linux.PathNameEncode(deviceJoinPath(prefix, relativeDestPath))
 	devPath := filepath.Join(devicesPath, devName)
 
+	if mirrorPath {
+		devPath = filepath.Join(devicesPath, unixDeviceMirrorDir, linux.PathNameEncode(prefix), relativeDestPath)
+
+		err := os.MkdirAll(filepath.Dir(devPath), 0o711)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to create device directory for %s: %w", devPath, err)
+		}
+	}
+
 	// Create the new entry.
 	if !s.OS.RunningInUserNS {
 		if s.OS.Nodev {
@@ -267,17 +360,13 @@ func unixDeviceSetup(s *state.State, devicesPath string, typePrefix string, devi
 	ourEncRelDestFile := linux.PathNameEncode(strings.TrimPrefix(ourDestPath, "/"))
 
 	// Load all existing host devices.
-	dents, err := os.ReadDir(devicesPath)
+	devFiles, err := unixDeviceFiles(devicesPath)
 	if err != nil {
-		if !errors.Is(err, fs.ErrNotExist) {
-			return err
-		}
+		return err
 	}
 
 	dupe := false
-	for _, ent := range dents {
-		devName := ent.Name()
-
+	for devName := range devFiles {
 		// Remove the device type and name prefix, leaving just the encoded dest path.
 		idx := strings.LastIndex(devName, ".")
 		if idx == -1 {
@@ -297,7 +386,7 @@ func unixDeviceSetup(s *state.State, devicesPath string, typePrefix string, devi
 
 	// Create the device on the host.
 	ourPrefix := deviceJoinPath(typePrefix, deviceName)
-	d, err := UnixDeviceCreate(s, nil, devicesPath, ourPrefix, m, defaultMode)
+	d, err := UnixDeviceCreate(s, nil, devicesPath, ourPrefix, m, defaultMode, util.IsTrue(m["mirror_path"]))
 	if err != nil {
 		return err
 	}
@@ -317,14 +406,27 @@ func unixDeviceSetup(s *state.State, devicesPath string, typePrefix string, devi
 	})
 
 	// Ask for cgroups to be configured.
-	runConf.CGroups = append(runConf.CGroups, deviceConfig.RunConfigItem{
-		Key:   "devices.allow",
-		Value: fmt.Sprintf("%s %d:%d rwm", d.Type, d.Major, d.Minor),
-	})
+	unixDeviceCGroupRule(runConf, "devices.allow", d.Type, d.Major, d.Minor)
 
 	return nil
 }
 
+// unixDeviceCGroupRule adds a cgroup rule for the given device to the supplied RunConfig, unless an
+// identical rule has already been added. This avoids duplicate rules when multiple device entries
+// resolve to the same major and minor numbers.
+func unixDeviceCGroupRule(runConf *deviceConfig.RunConfig, key string, dType string, major uint32, minor uint32) {
+	rule := deviceConfig.RunConfigItem{
+		Key:   key,
+		Value: fmt.Sprintf("%s %d:%d rwm", dType, major, minor),
+	}
+
+	if slices.Contains(runConf.CGroups, rule) {
+		return
+	}
+
+	runConf.CGroups = append(runConf.CGroups, rule)
+}
+
 // unixDeviceSetupCharNum calls unixDeviceSetup and overrides the supplied device config with the
 // type as "unix-char" and the supplied major and minor numbers. This function can be used when you
 // already know the device's major and minor numbers to avoid unixDeviceSetup() having to stat the
@@ -368,8 +470,11 @@ func UnixDeviceExists(devicesPath string, prefix string, path string) bool {
 	relativeDestPath := strings.TrimPrefix(path, "/")
 	devName := fmt.Sprintf("%s.%s", linux.PathNameEncode(prefix), linux.PathNameEncode(relativeDestPath))
 	devPath := filepath.Join(devicesPath, devName)
+	if util.PathExists(devPath) {
+		return true
+	}
 
-	return util.PathExists(devPath)
+	return util.PathExists(filepath.Join(devicesPath, unixDeviceMirrorDir, linux.PathNameEncode(prefix), relativeDestPath))
 }
 
 // unixRemoveDevice identifies all files related to the supplied typePrefix and deviceName and then
@@ -381,11 +486,9 @@ func UnixDeviceExists(devicesPath string, prefix string, path string) bool {
 // Accepts an optional file prefix that will be used to narrow the selection of files to remove.
 func unixDeviceRemove(devicesPath string, typePrefix string, deviceName string, optPrefix string, runConf *deviceConfig.RunConfig) error {
 	// Load all devices.
-	dents, err := os.ReadDir(devicesPath)
+	devFiles, err := unixDeviceFiles(devicesPath)
 	if err != nil {
-		if !errors.Is(err, fs.ErrNotExist) {
-			return err
-		}
+		return err
 	}
 
 	var ourPrefix string
@@ -399,9 +502,7 @@ func unixDeviceRemove(devicesPath string, typePrefix string, deviceName string,
 	ourDevs := []string{}
 	otherDevs := []string{}
 
-	for _, ent := range dents {
-		devName := ent.Name()
-
+	for devName := range devFiles {
 		// This device file belongs to our device.
 		if strings.HasPrefix(devName, ourPrefix) {
 			ourDevs = append(ourDevs, devName)
@@ -452,17 +553,14 @@ func unixDeviceRemove(devicesPath string, typePrefix string, deviceName string,
 			TargetPath: linux.PathNameDecode(ourEncRelDestFile),
 		})
 
-		absDevPath := filepath.Join(devicesPath, ourDev)
+		absDevPath := devFiles[ourDev]
 		dType, dMajor, dMinor, err := unixDeviceAttributes(absDevPath)
 		if err != nil {
 			return fmt.Errorf("Failed to get UNIX device attributes for '%s': %w", absDevPath, err)
 		}
 
 		// Append a deny cgroup rule for this device.
-		runConf.CGroups = append(runConf.CGroups, deviceConfig.RunConfigItem{
-			Key:   "devices.deny",
-			Value: fmt.Sprintf("%s %d:%d rwm", dType, dMajor, dMinor),
-		})
+		unixDeviceCGroupRule(runConf, "devices.deny", dType, dMajor, dMinor)
 	}
 
 	return nil
@@ -481,21 +579,15 @@ func unixDeviceDeleteFiles(s *state.State, devicesPath string, typePrefix string
 	}
 
 	// Load all devices.
-	dents, err := os.ReadDir(devicesPath)
+	devFiles, err := unixDeviceFiles(devicesPath)
 	if err != nil {
-		if !errors.Is(err, fs.ErrNotExist) {
-			return err
-		}
+		return err
 	}
 
 	// Remove our host side device files.
-	for _, ent := range dents {
-		devName := ent.Name()
-
+	for devName, devPath := range devFiles {
 		// This device file belongs to our device.
 		if strings.HasPrefix(devName, ourPrefix) {
-			devPath := filepath.Join(devicesPath, devName)
-
 			// Remove the host side mount.
 			if s.OS.RunningInUserNS {
 				_ = unix.Unmount(devPath, unix.MNT_DETACH)
@@ -506,6 +598,14 @@ func unixDeviceDeleteFiles(s *state.State, devicesPath string, typePrefix string
 			if err != nil {
 				return err
 			}
+
+			// Remove the now empty parent directories of devices created with a mirrored path.
+			for dir := filepath.Dir(devPath); dir != devicesPath && strings.HasPrefix(dir, devicesPath); dir = filepath.Dir(dir) {
+				err := os.Remove(dir)
+				if err != nil {
+					break
+				}
+			}
 		}
 	}
 
@@ -0,0 +1,151 @@
+package device
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/sys/unix"
+
+	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/internal/server/sys"
+)
+
+func Example_unixDeviceCGroupRule() {
+	runConf := deviceConfig.RunConfig{}
+
+	// Two device entries resolving to the same major and minor numbers.
+	unixDeviceCGroupRule(&runConf, "devices.allow", "c", 195, 0)
+	unixDeviceCGroupRule(&runConf, "devices.allow", "c", 195, 0)
+	unixDeviceCGroupRule(&runConf, "devices.allow", "c", 195, 255)
+	unixDeviceCGroupRule(&runConf, "devices.allow", "b", 195, 0)
+
+	// The same device on removal.
+	unixDeviceCGroupRule(&runConf, "devices.deny", "c", 195, 0)
+	unixDeviceCGroupRule(&runConf, "devices.deny", "c", 195, 0)
+
+	for _, rule := range runConf.CGroups {
+		fmt.Printf("%s: %s\n", rule.Key, rule.Value)
+	}
+
+	// Output: devices.allow: c 195:0 rwm
+	// devices.allow: c 195:255 rwm
+	// devices.allow: b 195:0 rwm
+	// devices.deny: c 195:0 rwm
+}
+
+// Two devices resolving to the same major and minor numbers get a single cgroup rule.
+func TestUnixDeviceSetupSharedNumbers(t *testing.T) {
+	devicesPath := t.TempDir()
+
+	// Device nodes can only be created with the CAP_MKNOD capability.
+	err := unix.Mknod(filepath.Join(devicesPath, "probe"), unix.S_IFCHR|0o600, int(unix.Mkdev(1, 3)))
+	if err != nil {
+		t.Skipf("Can't create device nodes: %v", err)
+	}
+
+	err = os.Remove(filepath.Join(devicesPath, "probe"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &state.State{OS: &sys.OS{}}
+	devices := map[string]deviceConfig.Device{
+		"null1": {"type": "unix-char", "path": "/dev/null1", "major": "1", "minor": "3"},
+		"null2": {"type": "unix-char", "path": "/dev/null2", "major": "1", "minor": "3"},
+	}
+
+	runConf := deviceConfig.RunConfig{}
+	for _, name := range []string{"null1", "null2"} {
+		err := unixDeviceSetup(s, devicesPath, "unix", name, devices[name], true, &runConf)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(runConf.Mounts) != 2 {
+		t.Errorf("Expected 2 mounts, got %d", len(runConf.Mounts))
+	}
+
+	expected := []deviceConfig.RunConfigItem{{Key: "devices.allow", Value: "c 1:3 rwm"}}
+	if !slices.Equal(runConf.CGroups, expected) {
+		t.Errorf("Expected cgroup rules %v, got %v", expected, runConf.CGroups)
+	}
+
+	runConf = deviceConfig.RunConfig{}
+	for _, name := range []string{"null1", "null2"} {
+		err := unixDeviceRemove(devicesPath, "unix", name, "", &runConf)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected = []deviceConfig.RunConfigItem{{Key: "devices.deny", Value: "c 1:3 rwm"}}
+	if !slices.Equal(runConf.CGroups, expected) {
+		t.Errorf("Expected cgroup rules %v, got %v", expected, runConf.CGroups)
+	}
+}
+
+func Example_unixDeviceFiles() {
+	devicesPath, err := os.MkdirTemp("", "incus_devices_")
+	if err != nil {
+		panic(err)
+	}
+
+	defer func() { _ = os.RemoveAll(devicesPath) }()
+
+	// A device using the encoded file name scheme, one using a mirrored path and a disk mount.
+	for _, path := range []string{"unix.gpu.dev-dri-card0", ".unix/unix.fuse/dev/fuse", "disk.data.mnt/file"} {
+		err := os.MkdirAll(filepath.Dir(filepath.Join(devicesPath, path)), 0o711)
+		if err != nil {
+			panic(err)
+		}
+
+		err = os.WriteFile(filepath.Join(devicesPath, path), nil, 0o600)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	devFiles, err := unixDeviceFiles(devicesPath)
+	if err != nil {
+		panic(err)
+	}
+
+	devNames := []string{}
+	for devName := range devFiles {
+		devNames = append(devNames, devName)
+	}
+
+	slices.Sort(devNames)
+
+	for _, devName := range devNames {
+		fmt.Printf("%s: %s\n", devName, strings.TrimPrefix(devFiles[devName], devicesPath))
+	}
+
+	// Output: disk.data.mnt: /disk.data.mnt
+	// unix.fuse.dev-fuse: /.unix/unix.fuse/dev/fuse
+	// unix.gpu.dev-dri-card0: /unix.gpu.dev-dri-card0
+}
+
+func ExampleUnixDeviceValidate() {
+	tests := []deviceConfig.Device{
+		{"source": "/dev/fuse"},
+		{"path": "/dev/fuse"},
+		{"source": "/dev/fuse", "path": "/dev/fuse"},
+		{"type": "unix-char", "major": "10", "minor": "229"},
+	}
+
+	for _, m := range tests {
+		fmt.Printf("%q: %v\n", m, UnixDeviceValidate(m))
+	}
+
+	// Output: map["source":"/dev/fuse"]: <nil>
+	// map["path":"/dev/fuse"]: <nil>
+	// map["path":"/dev/fuse" "source":"/dev/fuse"]: <nil>
+	// map["major":"10" "minor":"229" "type":"unix-char"]: Unix device entry is missing the required "source" or "path" property
+}
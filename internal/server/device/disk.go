@@ -625,10 +625,19 @@ func (d *disk) validateConfig(instConf instance.ConfigReader) error {
 
 				if contentType == db.StoragePoolVolumeContentTypeBlock {
 					if instConf.Type() == instancetype.Container {
-						return errors.New("Custom block volumes cannot be used on containers")
-					}
+						// Some drivers can pass the volume's block device through as a device node.
+						if !d.pool.Driver().Info().ContainerBlockVolumes {
+							return errors.New("Custom block volumes cannot be used on containers")
+						}
 
-					if d.config["path"] != "" {
+						if d.config["path"] == "" {
+							return errors.New("Custom block volumes used on containers require a path to be defined")
+						}
+
+						if util.IsTrue(d.config["readonly"]) {
+							return errors.New("Custom block volumes used on containers cannot be read-only")
+						}
+					} else if d.config["path"] != "" {
 						return errors.New("Custom block volumes cannot have a path defined")
 					}
 
@@ -1010,6 +1019,25 @@ func (d *disk) startContainer() (*deviceConfig.RunConfig, error) {
 
 				return nil
 			})
+
+			// Custom block volumes are passed through as a device node rather than mounted.
+			if linux.IsBlockdevPath(srcPath) {
+				_, major, minor, err := unixDeviceAttributes(srcPath)
+				if err != nil {
+					return nil, fmt.Errorf("Failed to get device attributes for %q: %w", srcPath, err)
+				}
+
+				err = unixDeviceSetupBlockNum(d.state, d.inst.DevicesPath(), "disk", d.name, d.config, major, minor, destPath, true, &runConf)
+				if err != nil {
+					return nil, err
+				}
+
+				reverter.Add(func() { _ = unixDeviceDeleteFiles(d.state, d.inst.DevicesPath(), "disk", d.name, "") })
+
+				reverter.Success()
+
+				return &runConf, nil
+			}
 		}
 
 		// Mount the source in the instance devices directory.
@@ -1858,7 +1886,7 @@ func (d *disk) mountPoolVolume() (func(), string, *storagePools.MountInfo, error
 			if err != nil {
 				return nil, "", nil, fmt.Errorf("Failed shifting custom storage volume %q on storage pool %q: %w", volName, d.pool.Name(), err)
 			}
-		} else {
+		} else if dbVolume.ContentType != db.StoragePoolVolumeContentTypeNameBlock || !d.pool.Driver().Info().ContainerBlockVolumes {
 			return nil, "", nil, errors.New("Only filesystem volumes are supported for containers")
 		}
 	}
@@ -2280,6 +2308,16 @@ func (d *disk) Stop() (*deviceConfig.RunConfig, error) {
 		return nil, nil
 	}
 
+	// Custom block volumes are passed through as a device node, so remove it like a unix-block device.
+	if linux.IsBlockdevPath(devPath) {
+		err := unixDeviceRemove(d.inst.DevicesPath(), "disk", d.name, "", &runConf)
+		if err != nil {
+			return nil, err
+		}
+
+		return &runConf, nil
+	}
+
 	// Request an unmount of the device inside the instance.
 	runConf.Mounts = append(runConf.Mounts, deviceConfig.MountEntryItem{
 		TargetPath: relativeDestPath,
@@ -87,6 +87,14 @@ func (d *unixCommon) validateConfig(instConf instance.ConfigReader) error {
 		//  shortdesc: Device minor number
 		"minor": unixValidDeviceNum,
 
+		// gendoc:generate(entity=devices, group=unix-char-block, key=mirror_path)
+		//
+		// ---
+		//  type: bool
+		//  default: false
+		//  shortdesc: Whether to create the device on the host under a directory tree mirroring its path inside the instance
+		"mirror_path": validate.Optional(validate.IsBool),
+
 		// gendoc:generate(entity=devices, group=unix-char-block, key=mode)
 		//
 		// ---
@@ -124,8 +132,9 @@ func (d *unixCommon) validateConfig(instConf instance.ConfigReader) error {
 		return err
 	}
 
-	if d.config["source"] == "" && d.config["path"] == "" {
-		return errors.New("Unix device entry is missing the required \"source\" or \"path\" property")
+	err = UnixDeviceValidate(d.config)
+	if err != nil {
+		return err
 	}
 
 	return nil
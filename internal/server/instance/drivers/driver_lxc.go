@@ -8347,7 +8347,7 @@ func (d *lxc) InsertSeccompUnixDevice(prefix string, m deviceConfig.Device, pid
 		return err
 	}
 
-	dev, err := device.UnixDeviceCreate(d.state, idmapSet, d.DevicesPath(), prefix, m, true)
+	dev, err := device.UnixDeviceCreate(d.state, idmapSet, d.DevicesPath(), prefix, m, true, false)
 	if err != nil {
 		return fmt.Errorf("Failed to setup device: %s", err)
 	}
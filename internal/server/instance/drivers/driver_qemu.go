@@ -6227,6 +6227,8 @@ func (d *qemu) Update(args db.InstanceArgs, userRequested bool) error {
 			"security.csm",
 			"security.protection.delete",
 			"security.guestapi",
+			"security.guestapi.devices",
+			"security.guestapi.events",
 			"security.secureboot",
 		}
 
@@ -9389,6 +9391,11 @@ func (d *qemu) cpuTopology(limit string) (*cpuTopology, error) {
 }
 
 func (d *qemu) devIncusEventSend(eventType string, eventMessage map[string]any) error {
+	// The agent relays events to the guest as-is, so skip them if the guest isn't allowed to receive them.
+	if util.IsFalse(d.expandedConfig["security.guestapi.events"]) {
+		return nil
+	}
+
 	event := jmap.Map{}
 	event["type"] = eventType
 	event["timestamp"] = time.Now()
@@ -37,6 +37,21 @@ func (a *Addr) Add() error {
 	return nil
 }
 
+// Delete deletes the protocol address.
+func (a *Addr) Delete() error {
+	link, err := linkByName(a.DevName)
+	if err != nil {
+		return err
+	}
+
+	err = netlink.AddrDel(link, &netlink.Addr{IPNet: a.Address})
+	if err != nil {
+		return fmt.Errorf("Failed to delete address %q: %w", a.Address.String(), err)
+	}
+
+	return nil
+}
+
 func (a *Addr) scopeNum() (int, error) {
 	var scope netlink.Scope
 	switch a.Scope {
@@ -56,3 +56,22 @@ func TestMetricSet_FilterSamples(t *testing.T) {
 		require.Contains(t, hasKeys, "project")
 	}
 }
+
+func TestMetricSet_Histogram(t *testing.T) {
+	m := NewMetricSet(nil)
+	m.AddSamples(StorageDriverOperationSeconds,
+		Sample{Value: 1, Labels: map[string]string{"operation": "CreateVolume", "le": "1"}, Suffix: "_bucket"},
+		Sample{Value: 2, Labels: map[string]string{"operation": "CreateVolume", "le": "+Inf"}, Suffix: "_bucket"},
+		Sample{Value: 3.5, Labels: map[string]string{"operation": "CreateVolume"}, Suffix: "_sum"},
+		Sample{Value: 2, Labels: map[string]string{"operation": "CreateVolume"}, Suffix: "_count"},
+	)
+
+	require.Equal(t, `# HELP incus_storage_driver_operation_seconds The duration of storage driver operations in seconds.
+# TYPE incus_storage_driver_operation_seconds histogram
+incus_storage_driver_operation_seconds_bucket{le="1",operation="CreateVolume"} 1
+incus_storage_driver_operation_seconds_bucket{le="+Inf",operation="CreateVolume"} 2
+incus_storage_driver_operation_seconds_sum{operation="CreateVolume"} 3.5
+incus_storage_driver_operation_seconds_count{operation="CreateVolume"} 2
+# EOF
+`, m.String())
+}
@@ -1395,6 +1395,10 @@ func (n *common) loadBalancerValidate(listenAddress net.IP, forward *api.Network
 			return nil, fmt.Errorf("Target address is not within the network subnet for backend %q", backendSpec.Name)
 		}
 
+		if backendSpec.Weight < 0 {
+			return nil, fmt.Errorf("Invalid weight for backend %q, must be a positive integer", backendSpec.Name)
+		}
+
 		// Check valid target port(s) supplied.
 		target := forwardTarget{
 			address: targetAddress,
@@ -19,6 +19,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/minio/minio-go/v7"
 	"golang.org/x/sync/errgroup"
 	"gopkg.in/yaml.v2"
@@ -29,6 +30,7 @@ import (
 	internalIO "github.com/lxc/incus/v6/internal/io"
 	"github.com/lxc/incus/v6/internal/linux"
 	"github.com/lxc/incus/v6/internal/migration"
+	"github.com/lxc/incus/v6/internal/server/apparmor"
 	"github.com/lxc/incus/v6/internal/server/backup"
 	backupConfig "github.com/lxc/incus/v6/internal/server/backup/config"
 	"github.com/lxc/incus/v6/internal/server/cluster/request"
@@ -250,6 +252,67 @@ func (b *backend) GetResources() (*api.ResourcesStoragePool, error) {
 	return b.driver.GetResources()
 }
 
+// Audit reports inconsistencies found on the storage pool without repairing them.
+func (b *backend) Audit(op *operations.Operation) (map[string]string, error) {
+	l := b.logger.AddContext(nil)
+	l.Debug("Audit started")
+	defer l.Debug("Audit finished")
+
+	err := b.isStatusReady()
+	if err != nil {
+		return nil, err
+	}
+
+	return b.driver.Audit(op)
+}
+
+// ReconcileVolumes lets the driver repair the metadata it keeps on the storage for the custom volumes
+// recorded in the database, returning the changes made.
+func (b *backend) ReconcileVolumes(op *operations.Operation) ([]string, error) {
+	l := b.logger.AddContext(nil)
+	l.Debug("ReconcileVolumes started")
+	defer l.Debug("ReconcileVolumes finished")
+
+	err := b.isStatusReady()
+	if err != nil {
+		return nil, err
+	}
+
+	var dbVols []*db.StorageVolume
+
+	volDBType := db.StoragePoolVolumeTypeCustom
+	err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+		dbVols, err = tx.GetStoragePoolVolumes(ctx, b.ID(), true, db.StorageVolumeFilter{Type: &volDBType})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	vols := make([]drivers.Volume, 0, len(dbVols))
+	for _, dbVol := range dbVols {
+		// Snapshots share the metadata of their volume.
+		if internalInstance.IsSnapshot(dbVol.Name) {
+			continue
+		}
+
+		volStorageName := project.StorageVolume(dbVol.Project, dbVol.Name)
+		vols = append(vols, b.GetVolume(drivers.VolumeTypeCustom, drivers.ContentType(dbVol.ContentType), volStorageName, dbVol.Config))
+	}
+
+	changes, err := b.driver.ReconcileVolumes(vols)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, change := range changes {
+		l.Info("Reconciled storage volume", logger.Ctx{"change": change})
+	}
+
+	return changes, nil
+}
+
 // IsUsed returns whether the storage pool is used by any volumes or profiles (excluding image volumes).
 func (b *backend) IsUsed() (bool, error) {
 	usedBy, err := UsedBy(context.TODO(), b.state, b, true, true, db.StoragePoolVolumeTypeNameImage)
@@ -1075,12 +1138,18 @@ func (b *backend) CreateInstanceFromCopy(inst instance.Instance, src instance.In
 
 	reverter.Add(func() { _ = b.DeleteInstance(inst, op) })
 
-	if b.Name() == srcPool.Name() {
-		l.Debug("CreateInstanceFromCopy same-pool mode detected")
+	// If the source and target are in the same pool, or the driver can copy directly from the source
+	// pool, then use CreateVolumeFromCopy rather than migration system as it will be quicker.
+	if b.Name() == srcPool.Name() || b.driver.CanCopyVolumesFrom(srcPoolBackend.driver) {
+		if b.Name() == srcPool.Name() {
+			l.Debug("CreateInstanceFromCopy same-pool mode detected")
+		} else {
+			l.Debug("CreateInstanceFromCopy direct cross-pool mode detected")
+		}
 
 		// Get the src volume name on storage.
 		srcVolStorageName := project.Instance(src.Project().Name, src.Name())
-		srcVol := b.GetVolume(volType, contentType, srcVolStorageName, srcConfig.Volume.Config)
+		srcVol := srcPoolBackend.GetVolume(volType, contentType, srcVolStorageName, srcConfig.Volume.Config)
 
 		// Validate config and create database entry for new storage volume.
 		err = VolumeDBCreate(b, inst.Project().Name, inst.Name(), "", vol.Type(), false, vol.Config(), inst.CreationDate(), time.Time{}, contentType, false, true)
@@ -4770,10 +4839,14 @@ func (b *backend) CreateCustomVolumeFromCopy(projectName string, srcProjectName
 	srcVolStorageName := project.StorageVolume(srcProjectName, srcVolName)
 	srcVol := srcPool.GetVolume(drivers.VolumeTypeCustom, contentType, srcVolStorageName, srcConfig.Volume.Config)
 
-	// If the source and target are in the same pool then use CreateVolumeFromCopy rather than
-	// migration system as it will be quicker.
-	if srcPool == b {
-		l.Debug("CreateCustomVolumeFromCopy same-pool mode detected")
+	// If the source and target are in the same pool, or the driver can copy directly from the source
+	// pool, then use CreateVolumeFromCopy rather than migration system as it will be quicker.
+	if srcPool == b || b.driver.CanCopyVolumesFrom(srcPool.Driver()) {
+		if srcPool == b {
+			l.Debug("CreateCustomVolumeFromCopy same-pool mode detected")
+		} else {
+			l.Debug("CreateCustomVolumeFromCopy direct cross-pool mode detected")
+		}
 
 		// Get the volume name on storage.
 		volStorageName := project.StorageVolume(projectName, volName)
@@ -5749,6 +5822,30 @@ func (b *backend) GetCustomVolumeDisk(projectName, volName string) (string, erro
 	return b.driver.GetVolumeDiskPath(vol)
 }
 
+// GetVolumeInfo returns the driver specific details of a custom or instance volume.
+func (b *backend) GetVolumeInfo(projectName string, volType drivers.VolumeType, volName string) (map[string]string, error) {
+	err := b.isStatusReady()
+	if err != nil {
+		return nil, err
+	}
+
+	volume, err := VolumeDBGet(b, projectName, volName, volType)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the volume name on storage.
+	volStorageName := project.Instance(projectName, volName)
+	if volType == drivers.VolumeTypeCustom {
+		volStorageName = project.StorageVolume(projectName, volName)
+	}
+
+	// Pass the config as drivers may need it to locate the volume.
+	vol := b.GetVolume(volType, drivers.ContentType(volume.ContentType), volStorageName, volume.Config)
+
+	return b.driver.GetVolumeInfo(vol)
+}
+
 // GetCustomVolumeUsage returns the disk space used by the custom volume.
 func (b *backend) GetCustomVolumeUsage(projectName, volName string) (*VolumeUsage, error) {
 	err := b.isStatusReady()
@@ -5766,8 +5863,8 @@ func (b *backend) GetCustomVolumeUsage(projectName, volName string) (*VolumeUsag
 	// Get the volume name on storage.
 	volStorageName := project.StorageVolume(projectName, volName)
 
-	// There's no need to pass config as it's not needed when getting the volume usage.
-	vol := b.GetVolume(drivers.VolumeTypeCustom, drivers.ContentType(volume.ContentType), volStorageName, nil)
+	// Pass the config as drivers may need it to locate the volume.
+	vol := b.GetVolume(drivers.VolumeTypeCustom, drivers.ContentType(volume.ContentType), volStorageName, volume.Config)
 
 	// Get the usage.
 	size, err := b.driver.GetVolumeUsage(vol)
@@ -7121,6 +7218,94 @@ func (b *backend) BackupCustomVolume(projectName string, volName string, tarWrit
 	return nil
 }
 
+// ExportVolumeDisk converts a custom or virtual machine block volume, or one of its snapshots, into a qcow2
+// image at targetPath. Volumes which aren't snapshots are exported from a temporary snapshot.
+func (b *backend) ExportVolumeDisk(projectName string, volType drivers.VolumeType, volName string, targetPath string, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"project": projectName, "type": volType, "volume": volName, "target": targetPath})
+	l.Debug("ExportVolumeDisk started")
+	defer l.Debug("ExportVolumeDisk finished")
+
+	err := b.isStatusReady()
+	if err != nil {
+		return err
+	}
+
+	// Get the volume name on storage.
+	var volStorageName string
+	switch volType {
+	case drivers.VolumeTypeCustom:
+		volStorageName = project.StorageVolume(projectName, volName)
+	case drivers.VolumeTypeVM:
+		volStorageName = project.Instance(projectName, volName)
+	default:
+		return fmt.Errorf("Volumes of type %q can't be exported as a disk image: %w", volType, drivers.ErrNotSupported)
+	}
+
+	volume, err := VolumeDBGet(b, projectName, volName, volType)
+	if err != nil {
+		return err
+	}
+
+	if drivers.ContentType(volume.ContentType) != drivers.ContentTypeBlock {
+		return fmt.Errorf("Only block volumes can be exported as a disk image: %w", drivers.ErrNotSupported)
+	}
+
+	vol := b.GetVolume(volType, drivers.ContentTypeBlock, volStorageName, volume.Config)
+
+	// Export from a temporary snapshot to get a consistent view of a volume which may be in use.
+	if !vol.IsSnapshot() {
+		snapName := fmt.Sprintf("incus-export-%s", uuid.New().String())
+		vol = b.GetVolume(volType, drivers.ContentTypeBlock, drivers.GetSnapshotVolumeName(volStorageName, snapName), volume.Config)
+
+		err = b.driver.CreateVolumeSnapshot(vol, op)
+		if err != nil {
+			return fmt.Errorf("Failed creating temporary snapshot: %w", err)
+		}
+
+		defer func() {
+			err := b.driver.DeleteVolumeSnapshot(vol, op)
+			if err != nil {
+				l.Warn("Failed deleting temporary snapshot", logger.Ctx{"err": err})
+			}
+		}()
+	}
+
+	err = b.driver.MountVolumeSnapshot(vol, op)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _, _ = b.driver.UnmountVolumeSnapshot(vol, op) }()
+
+	diskPath, err := b.driver.GetVolumeDiskPath(vol)
+	if err != nil {
+		return err
+	}
+
+	var tracker *ioprogress.ProgressTracker
+	if op != nil {
+		metadata := make(map[string]any)
+		tracker = &ioprogress.ProgressTracker{
+			Handler: func(percent, speed int64) {
+				operations.SetProgressMetadata(metadata, "export_volume_disk", "Converting volume", percent, 0, speed)
+				_ = op.UpdateMetadata(metadata)
+			},
+		}
+	}
+
+	cmd := []string{
+		"nice", "-n19", // Run with low priority to reduce CPU impact on other processes.
+		"qemu-img", "convert", "-p", "-f", "raw", "-O", "qcow2", diskPath, targetPath,
+	}
+
+	_, err = apparmor.QemuImg(b.state.OS, cmd, diskPath, targetPath, tracker)
+	if err != nil {
+		return fmt.Errorf("Failed converting volume to qcow2: %w", err)
+	}
+
+	return nil
+}
+
 func (b *backend) CreateCustomVolumeFromISO(projectName string, volName string, srcData io.ReadSeeker, size int64, op *operations.Operation) error {
 	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volume": volName})
 	l.Debug("CreateCustomVolumeFromISO started")
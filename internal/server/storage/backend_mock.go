@@ -73,6 +73,14 @@ func (b *mockBackend) GetResources() (*api.ResourcesStoragePool, error) {
 	return nil, nil
 }
 
+func (b *mockBackend) Audit(op *operations.Operation) (map[string]string, error) {
+	return nil, nil
+}
+
+func (b *mockBackend) ReconcileVolumes(op *operations.Operation) ([]string, error) {
+	return nil, nil
+}
+
 func (b *mockBackend) IsUsed() (bool, error) {
 	return false, nil
 }
@@ -359,6 +367,14 @@ func (b *mockBackend) CreateCustomVolumeFromISO(projectName string, volName stri
 	return nil
 }
 
+func (b *mockBackend) GetVolumeInfo(projectName string, volType drivers.VolumeType, volName string) (map[string]string, error) {
+	return nil, nil
+}
+
+func (b *mockBackend) ExportVolumeDisk(projectName string, volType drivers.VolumeType, volName string, targetPath string, op *operations.Operation) error {
+	return nil
+}
+
 // GenerateBucketBackupConfig returns the backup config entry for this bucket.
 func (b *mockBackend) GenerateBucketBackupConfig(projectName string, bucketName string, op *operations.Operation) (*backupConfig.Config, error) {
 	return nil, nil
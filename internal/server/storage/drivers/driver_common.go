@@ -255,6 +255,11 @@ func (d *common) ApplyPatch(name string) error {
 	return patch()
 }
 
+// Audit reports inconsistencies found on the storage pool without repairing them.
+func (d *common) Audit(op *operations.Operation) (map[string]string, error) {
+	return nil, ErrNotSupported
+}
+
 // moveGPTAltHeader moves the GPT alternative header to the end of the disk device supplied.
 // If the device supplied is not detected as not being a GPT disk then no action is taken and nil is returned.
 // If the required sgdisk command is not available a warning is logged, but no error is returned, as really it is
@@ -342,6 +347,11 @@ func (d *common) CreateVolumeFromCopy(vol Volume, srcVol Volume, copySnapshots b
 	return ErrNotSupported
 }
 
+// CanCopyVolumesFrom returns whether CreateVolumeFromCopy can copy volumes directly from another pool's driver.
+func (d *common) CanCopyVolumesFrom(srcDriver Driver) bool {
+	return false
+}
+
 // CreateVolumeFromMigration creates a new volume (with or without snapshots) from a migration data stream.
 func (d *common) CreateVolumeFromMigration(vol Volume, conn io.ReadWriteCloser, volTargetArgs localMigration.VolumeTargetArgs, preFiller *VolumeFiller, op *operations.Operation) error {
 	return ErrNotSupported
@@ -392,6 +402,16 @@ func (d *common) ListVolumes() ([]Volume, error) {
 	return nil, ErrNotSupported
 }
 
+// GetVolumeInfo returns driver specific details about a volume.
+func (d *common) GetVolumeInfo(vol Volume) (map[string]string, error) {
+	return nil, ErrNotSupported
+}
+
+// ReconcileVolumes repairs the metadata kept on the storage for the given volumes, returning the changes made.
+func (d *common) ReconcileVolumes(vols []Volume) ([]string, error) {
+	return nil, ErrNotSupported
+}
+
 // MountVolume sets up the volume for use.
 func (d *common) MountVolume(vol Volume, op *operations.Operation) error {
 	return ErrNotSupported
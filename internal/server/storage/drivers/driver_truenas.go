@@ -9,6 +9,9 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
+
+	"golang.org/x/sys/unix"
 
 	"github.com/lxc/incus/v6/internal/migration"
 	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
@@ -136,6 +139,7 @@ func (d *truenas) Info() Info {
 		IOUring:                      false,
 		MountedRoot:                  false,
 		Buckets:                      false,
+		ContainerBlockVolumes:        true,
 	}
 
 	return info
@@ -145,8 +149,9 @@ func (d *truenas) Info() Info {
 // Accepts warnOnExistingPolicyApplyError argument, if true will warn rather than fail if applying current policy
 // to an existing dataset fails.
 func (d *truenas) ensureInitialDatasets(warnOnExistingPolicyApplyError bool) error {
-	args := make([]string, 0, len(tnDefaultSettings))
-	for k, v := range tnDefaultSettings {
+	defaults := tnDatasetDefaults(d.config["truenas.dataset_defaults"])
+	args := make([]string, 0, len(defaults))
+	for k, v := range defaults {
 		args = append(args, fmt.Sprintf("%s=%s", k, v))
 	}
 
@@ -260,6 +265,52 @@ func (d *truenas) Create() error {
 		return err
 	}
 
+	err = tnValidateDatasetNameLength(d.config["truenas.dataset"], d.snapshotPrefix())
+	if err != nil {
+		return err
+	}
+
+	// Instance and snapshot names can be up to 63 characters, warn if those wouldn't fit below the dataset.
+	suffixLen := tnDatasetSuffixLen(d.snapshotPrefix(), 63, 63)
+	if len(d.config["truenas.dataset"])+suffixLen > tnMaxDatasetNameLen {
+		d.logger.Warn("Storage pool dataset name is long, volumes or snapshots with long names may fail to be created", logger.Ctx{"dataset": d.config["truenas.dataset"], "maxNamesLen": tnMaxDatasetNameLen - len(d.config["truenas.dataset"]) - tnDatasetSuffixLen(d.snapshotPrefix(), 0, 0)})
+	}
+
+	// Only report on whether the pool could be created.
+	if util.IsTrue(d.config["truenas.dry_run"]) {
+		err = d.checkCreate()
+		if err != nil {
+			return fmt.Errorf("Storage pool can't be created on TrueNAS host: %w", err)
+		}
+
+		return errors.New("Storage pool can be created on TrueNAS host (unset truenas.dry_run to create it)")
+	}
+
+	// Pools nested in one another would manage each other's datasets.
+	err = d.checkNestedPool()
+	if err != nil {
+		return err
+	}
+
+	// Read-only pools attach to an existing dataset tree as is.
+	if d.isReadonly() {
+		exists, err := d.datasetExists(d.config["truenas.dataset"])
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			return fmt.Errorf("Remote TrueNAS dataset %q doesn't exist, read-only pools can only use an existing dataset", d.config["truenas.dataset"])
+		}
+
+		err = d.verifyIscsiFunctionality(false)
+		if err != nil {
+			return fmt.Errorf("Unable to verify TrueNAS iSCSI service (requires %s v%s or later): %w", tnToolName, tnMinVersion, err)
+		}
+
+		return nil
+	}
+
 	// create pool dataset
 	exists, err := d.datasetExists(d.config["truenas.dataset"])
 	if err != nil {
@@ -267,7 +318,14 @@ func (d *truenas) Create() error {
 	}
 
 	if !exists {
-		err = d.createDataset(d.config["truenas.dataset"])
+		var opts []string
+
+		// Volumes inherit the encryption of the pool dataset, with the key managed by the TrueNAS host.
+		if util.IsTrue(d.config["truenas.encryption"]) {
+			opts = append(opts, "encryption=on")
+		}
+
+		err = d.createDataset(d.config["truenas.dataset"], opts...)
 		if err != nil {
 			return fmt.Errorf("Failed to create storage pool on TrueNAS host: %s, err: %w", d.config["source"], err)
 		}
@@ -283,6 +341,18 @@ func (d *truenas) Create() error {
 		}
 	}
 
+	if exists && util.IsTrue(d.config["truenas.encryption"]) {
+		// An existing dataset can't be encrypted after the fact.
+		encryption, err := d.getDatasetProperty(d.config["truenas.dataset"], "encryption")
+		if err != nil {
+			return err
+		}
+
+		if encryption == "" || encryption == "off" {
+			return fmt.Errorf("Remote TrueNAS dataset %q isn't encrypted", d.config["truenas.dataset"])
+		}
+	}
+
 	// Setup revert in case of problems
 	reverter := revert.New()
 	defer reverter.Fail()
@@ -291,21 +361,101 @@ func (d *truenas) Create() error {
 
 	err = d.verifyIscsiFunctionality(false) // ensureSetup
 	if err != nil {
-		return fmt.Errorf("Unable to verify TrueNAS iSCSI service: %v", err)
+		return fmt.Errorf("Unable to verify TrueNAS iSCSI service (requires %s v%s or later): %w", tnToolName, tnMinVersion, err)
 	}
 
 	reverter.Success()
 	return nil
 }
 
-// Delete removes the storage pool from the storage device.
-func (d *truenas) Delete(op *operations.Operation) error {
-	// Check if the dataset/pool is already gone.
+// checkCreate verifies that the storage pool can be created without making any changes on the remote host.
+// All the problems found are reported at once.
+func (d *truenas) checkCreate() error {
+	var errs []error
+
+	// Check connectivity, credentials and that the dataset can be used.
 	exists, err := d.datasetExists(d.config["truenas.dataset"])
+	if err != nil {
+		errs = append(errs, err)
+	} else if exists && util.IsFalseOrEmpty(d.config["truenas.force_reuse"]) {
+		datasets, err := d.getDatasets(d.config["truenas.dataset"], "all")
+		if err != nil {
+			errs = append(errs, err)
+		} else if len(datasets) > 0 {
+			errs = append(errs, fmt.Errorf("Remote TrueNAS dataset isn't empty: %s", d.config["truenas.dataset"]))
+		}
+	}
+
+	err = d.checkNestedPool()
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	// Check that volumes can be shared with this host.
+	err = d.verifyIscsiFunctionality(false)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("Unable to verify TrueNAS iSCSI service (requires %s v%s or later): %w", tnToolName, tnMinVersion, err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkNestedPool refuses a pool dataset that is inside, or contains, the datasets of another Incus storage pool
+// on the TrueNAS host, based on the "managedby" property set on the datasets Incus creates.
+func (d *truenas) checkNestedPool() error {
+	zpool, _, _ := strings.Cut(d.config["truenas.dataset"], "/")
+
+	out, err := d.runTool("list", "--no-headers", "-o", "name,managedby", "-r", "-t", "filesystem", zpool)
 	if err != nil {
 		return err
 	}
 
+	managedBy, err := tnParseDatasetProperty(out)
+	if err != nil {
+		return err
+	}
+
+	err = tnNestedPoolConflict(d.config["truenas.dataset"], managedBy, util.IsTrue(d.config["truenas.force_reuse"]))
+	if err != nil {
+		return fmt.Errorf("Storage pool can't be nested with another Incus storage pool: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the storage pool from the storage device.
+func (d *truenas) Delete(op *operations.Operation) error {
+	// Refuse to tear down the pool while its volumes are still mounted on this host, as wiping the pool's
+	// mount path would then reach into them.
+	mounts, err := d.activeMounts()
+	if err != nil {
+		return fmt.Errorf("Failed listing active mounts: %w", err)
+	}
+
+	if len(mounts) > 0 {
+		if util.IsFalseOrEmpty(d.config["truenas.force_unmount"]) {
+			return fmt.Errorf("TrueNAS pool has active mounts (stop the instances using them or set truenas.force_unmount): %s", strings.Join(mounts, ", "))
+		}
+
+		for _, mountPath := range mounts {
+			d.logger.Warn("Lazily unmounting TrueNAS volume on pool deletion", logger.Ctx{"path": mountPath, "holders": tnMountHolders(mountPath)})
+
+			err := TryUnmount(mountPath, unix.MNT_DETACH)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Check if the dataset/pool is already gone. Read-only pools leave the datasets on the TrueNAS host.
+	exists := false
+	if !d.isReadonly() {
+		exists, err = d.datasetExists(d.config["truenas.dataset"])
+		if err != nil {
+			return err
+		}
+	}
+
 	if exists {
 		// Confirm that nothing's been left behind
 		datasets, err := d.getDatasets(d.config["truenas.dataset"], "all")
@@ -361,11 +511,43 @@ func (d *truenas) Validate(config map[string]string) error {
 		"truenas.portal":    validate.IsAny,
 
 		// controls behaviour of the driver
-		"truenas.clone_copy":  validate.Optional(validate.IsBool),
-		"truenas.force_reuse": validate.Optional(validate.IsBool),
+		"truenas.clone_copy":            validate.Optional(validate.IsBool),
+		"truenas.dataset_defaults":      validate.Optional(tnValidateDatasetDefaults),
+		"truenas.delete_retention":      validate.Optional(tnValidateExpiry),
+		"truenas.dry_run":               validate.Optional(validate.IsBool),
+		"truenas.encryption":            validate.Optional(validate.IsBool),
+		"truenas.force_reuse":           validate.Optional(validate.IsBool),
+		"truenas.force_unmount":         validate.Optional(validate.IsBool),
+		"truenas.image_restore":         validate.Optional(validate.IsOneOf("exact", "grow", "always")),
+		"truenas.image_shares":          validate.Optional(validate.IsBool),
+		"truenas.max_concurrent_ops":    validate.Optional(validate.IsUint32),
+		"truenas.mount_options":         validate.IsAny,
+		"truenas.readonly":              validate.Optional(validate.IsBool),
+		"truenas.snapshot_prefix":       validate.Optional(tnValidateSnapshotPrefix),
+		"truenas.snapshot_sync_timeout": validate.Optional(validate.IsMinimumDuration(time.Second)),
+		"truenas.strict_unmount":        validate.Optional(validate.IsBool),
+		"truenas.transfer_limit":        validate.Optional(validate.IsSize),
+	}
+
+	err := d.validatePool(config, rules, d.commonVolumeRules())
+	if err != nil {
+		return err
+	}
+
+	// Check the pool dataset leaves room for the volumes (it is filled from source on creation).
+	if config["truenas.dataset"] != "" {
+		snapPrefix := config["truenas.snapshot_prefix"]
+		if snapPrefix == "" {
+			snapPrefix = tnDefaultSnapPrefix
+		}
+
+		err = tnValidateDatasetNameLength(config["truenas.dataset"], snapPrefix)
+		if err != nil {
+			return err
+		}
 	}
 
-	return d.validatePool(config, rules, d.commonVolumeRules())
+	return nil
 }
 
 // Update applies any driver changes required from a configuration change.
@@ -375,6 +557,30 @@ func (d *truenas) Update(changedConfig map[string]string) error {
 		return errors.New("truenas.dataset cannot be modified")
 	}
 
+	_, ok = changedConfig["truenas.snapshot_prefix"]
+	if ok {
+		return errors.New("truenas.snapshot_prefix cannot be modified")
+	}
+
+	_, ok = changedConfig["truenas.encryption"]
+	if ok {
+		return errors.New("truenas.encryption cannot be modified")
+	}
+
+	// Re-apply the dataset defaults to the existing datasets.
+	value, ok := changedConfig["truenas.dataset_defaults"]
+	if ok {
+		err := d.checkWritable()
+		if err != nil {
+			return err
+		}
+
+		err = d.reapplyDatasetDefaults(tnDatasetDefaults(d.config["truenas.dataset_defaults"]), tnDatasetDefaults(value))
+		if err != nil {
+			return fmt.Errorf("Failed applying truenas.dataset_defaults: %w", err)
+		}
+	}
+
 	// prop changes we want to accept
 	props := []string{
 		"truenas.allow_insecure",
@@ -384,7 +590,18 @@ func (d *truenas) Update(changedConfig map[string]string) error {
 		"truenas.initiator",
 		"truenas.portal",
 		"truenas.clone_copy",
+		"truenas.dataset_defaults",
+		"truenas.delete_retention",
 		"truenas.force_reuse",
+		"truenas.force_unmount",
+		"truenas.image_restore",
+		"truenas.image_shares",
+		"truenas.max_concurrent_ops",
+		"truenas.mount_options",
+		"truenas.readonly",
+		"truenas.snapshot_sync_timeout",
+		"truenas.strict_unmount",
+		"truenas.transfer_limit",
 	}
 
 	for _, prop := range props {
@@ -402,6 +619,14 @@ func (d *truenas) Mount() (bool, error) {
 	// verify pool dataset exists
 	exists, err := d.datasetExists(d.config["truenas.dataset"])
 	if err != nil {
+		if errors.Is(err, ErrTrueNASAuth) {
+			return false, fmt.Errorf("TrueNAS host %q rejected the credentials, check truenas.api_key or truenas.config: %w", d.config["truenas.host"], err)
+		}
+
+		if errors.Is(err, ErrTrueNASUnreachable) {
+			return false, fmt.Errorf("TrueNAS host %q is unreachable, check truenas.host and the network connection: %w", d.config["truenas.host"], err)
+		}
+
 		return false, err
 	}
 
@@ -409,16 +634,43 @@ func (d *truenas) Mount() (bool, error) {
 		return false, fmt.Errorf("TrueNAS host is responding, but dataset is missing %s:%s", d.config["truenas.host"], d.config["truenas.dataset"])
 	}
 
-	// Apply our default configuration.
-	err = d.ensureInitialDatasets(true)
+	// The volumes of an encrypted pool can only be used once the TrueNAS host has loaded the key.
+	if util.IsTrue(d.config["truenas.encryption"]) {
+		keyStatus, err := d.getDatasetProperty(d.config["truenas.dataset"], "keystatus")
+		if err != nil {
+			return false, err
+		}
+
+		if keyStatus != "available" {
+			return false, fmt.Errorf("Encrypted TrueNAS dataset %s:%s is locked, unlock it on the TrueNAS host", d.config["truenas.host"], d.config["truenas.dataset"])
+		}
+	}
+
+	// Read-only pools leave the datasets as they are.
+	if !d.isReadonly() {
+		// Apply our default configuration.
+		err = d.ensureInitialDatasets(true)
+		if err != nil {
+			return false, err
+		}
+
+		// Get rid of the deleted volumes which are past their retention period.
+		err = d.purgeDeletedDatasets()
+		if err != nil {
+			d.logger.Warn("Failed purging expired deleted datasets", logger.Ctx{"err": err})
+		}
+	}
+
+	// Timestamps from the TrueNAS host are only coherent with ours if both clocks agree.
+	err = d.checkClockSkew()
 	if err != nil {
-		return false, err
+		d.logger.Warn("Failed checking the TrueNAS host clock", logger.Ctx{"err": err})
 	}
 
 	// As we have already created the storage pool, and it exists on the host, presumably we already had iscsi setup in the past, so restore it if necessary.
 	err = d.verifyIscsiFunctionality(true)
 	if err != nil {
-		return false, err
+		return false, fmt.Errorf("Unable to verify TrueNAS iSCSI service (requires %s v%s or later): %w", tnToolName, tnMinVersion, err)
 	}
 
 	return false, nil
@@ -456,10 +708,35 @@ func (d *truenas) GetResources() (*api.ResourcesStoragePool, error) {
 	res := api.ResourcesStoragePool{}
 	res.Space.Total = used + available
 	res.Space.Used = used
+	res.DriverInfo = d.driverInfo()
 
 	return &res, nil
 }
 
+// driverInfo reports the detected tool version and the availability of the features it gates, so
+// that version dependent behavior can be explained.
+func (d *truenas) driverInfo() map[string]string {
+	info := map[string]string{
+		"version":         tnVersion,
+		"minimum_version": tnMinVersion,
+		"iscsi":           "true",
+	}
+
+	// The iSCSI service is needed for all block volumes and block-backed filesystems.
+	err := d.cachedIscsiFunctionality()
+	if err != nil {
+		d.logger.Debug("TrueNAS iSCSI service unavailable", logger.Ctx{"err": err})
+		info["iscsi"] = "false"
+	}
+
+	return info
+}
+
+// Audit reports the leftover datasets of the pool without changing anything.
+func (d *truenas) Audit(op *operations.Operation) (map[string]string, error) {
+	return d.auditDatasets()
+}
+
 // MigrationTypes returns the type of transfer methods to be used when doing migrations between pools in preference order.
 func (d *truenas) MigrationTypes(contentType ContentType, refresh bool, copySnapshots bool, clusterMove bool, storageMove bool) []localMigration.Type {
 	// TODO: investigate "storageMove" that came from the linstor driver.
@@ -1,21 +1,35 @@
 package drivers
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"maps"
 	"math"
+	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	localMigration "github.com/lxc/incus/v6/internal/server/migration"
+	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/logger"
 	"github.com/lxc/incus/v6/shared/revert"
 	"github.com/lxc/incus/v6/shared/subprocess"
+	"github.com/lxc/incus/v6/shared/units"
 	"github.com/lxc/incus/v6/shared/util"
 )
 
@@ -23,6 +37,31 @@ const (
 	tnToolName            = "truenas_incus_ctl"
 	tnMinVersion          = "0.7.2" // deactivate --wait with sync functionality
 	tnDefaultVolblockSize = 16 * 1024
+	tnDefaultSnapPrefix   = "snapshot-"
+	tnMaxDatasetNameLen   = 255 // ZFS_MAX_DATASET_NAME_LEN without the terminating NUL.
+	tnRetainedTimeFormat  = "20060102T150405.000000000Z"
+)
+
+// Per pool slots bounding the number of in-flight tool invocations (truenas.max_concurrent_ops).
+var (
+	tnToolSlots   = map[string]chan struct{}{}
+	tnToolSlotsMu sync.Mutex
+)
+
+// User properties recording which Incus object a dataset was created for.
+const (
+	tnPropCreatedAt = "incus:created_at"
+	tnPropProject   = "incus:project"
+	tnPropInstance  = "incus:instance"
+
+	tnPropDeletedAt   = "incus:deleted_at"
+	tnPropDeletedFrom = "incus:deleted_from"
+
+	// Filesystem of block-backed filesystem volumes, so that recovered volumes don't need probing.
+	tnPropBlockFilesystem = "incus:block_filesystem"
+
+	// Parent dataset of custom volumes relocated through truenas.parent, so that they can be listed again.
+	tnPropParent = "incus:parent"
 )
 
 func (d *truenas) dataset(vol Volume, deleted bool) string {
@@ -32,27 +71,857 @@ func (d *truenas) dataset(vol Volume, deleted bool) string {
 		name = fmt.Sprintf("%s_%s", name, vol.ConfigBlockFilesystem())
 	}
 
-	if (vol.volType == VolumeTypeVM || vol.volType == VolumeTypeImage) && vol.contentType == ContentTypeBlock {
-		name = fmt.Sprintf("%s%s", name, zfsBlockVolSuffix)
-	} else if vol.volType == VolumeTypeCustom && vol.contentType == ContentTypeISO {
-		name = fmt.Sprintf("%s%s", name, zfsISOVolSuffix)
-	}
+	if (vol.volType == VolumeTypeVM || vol.volType == VolumeTypeImage) && vol.contentType == ContentTypeBlock {
+		name = fmt.Sprintf("%s%s", name, zfsBlockVolSuffix)
+	} else if vol.volType == VolumeTypeCustom && vol.contentType == ContentTypeISO {
+		name = fmt.Sprintf("%s%s", name, zfsISOVolSuffix)
+	}
+
+	if snapName != "" {
+		if deleted {
+			name = fmt.Sprintf("%s@deleted-%s", name, uuid.New().String())
+		} else {
+			name = fmt.Sprintf("%s@%s%s", name, d.snapshotPrefix(), snapName)
+		}
+	} else if deleted {
+		if vol.volType != VolumeTypeImage {
+			name = uuid.New().String()
+		}
+
+		return filepath.Join(d.config["truenas.dataset"], "deleted", string(vol.volType), name)
+	}
+
+	return filepath.Join(d.config["truenas.dataset"], d.datasetParent(vol), name)
+}
+
+// datasetParent returns the path of the dataset holding the volume, relative to the pool dataset.
+// Custom volumes can be relocated under a different parent through truenas.parent.
+func (d *truenas) datasetParent(vol Volume) string {
+	if vol.volType == VolumeTypeCustom && vol.config["truenas.parent"] != "" {
+		return vol.config["truenas.parent"]
+	}
+
+	return string(vol.volType)
+}
+
+// ensureDatasetParent creates the missing parent datasets of a custom volume relocated through truenas.parent.
+func (d *truenas) ensureDatasetParent(vol Volume) error {
+	if vol.volType != VolumeTypeCustom || vol.config["truenas.parent"] == "" {
+		return nil
+	}
+
+	parentDataset := d.config["truenas.dataset"]
+	for _, part := range strings.Split(d.datasetParent(vol), "/") {
+		parentDataset = filepath.Join(parentDataset, part)
+
+		exists, err := d.datasetExists(parentDataset)
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			err = d.createDataset(parentDataset)
+			if err != nil {
+				return fmt.Errorf("Failed creating parent dataset %q: %w", parentDataset, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// blockMountOptions returns the mount options for the filesystem of a volume, merging the pool's
+// truenas.mount_options defaults with the volume's own block.mount_options.
+func (d *truenas) blockMountOptions(vol Volume) string {
+	options := tnMergeMountOptions(d.config["truenas.mount_options"], vol.ConfigBlockMountOptions())
+
+	// Read-only pools only ever mount their volumes read-only, whatever the configured options.
+	if d.isReadonly() {
+		options = strings.TrimPrefix(options+",ro", ",")
+	}
+
+	return options
+}
+
+// ensureMountDir ensures that the volume's mount path is a directory before mounting on it. An empty file left
+// there (for example by an interrupted operation) is removed, anything else is reported rather than letting the
+// mount fail with a cryptic error.
+func (d *truenas) ensureMountDir(vol Volume) error {
+	mountPath := vol.MountPath()
+
+	info, err := os.Lstat(mountPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	if err == nil && !info.IsDir() {
+		if !info.Mode().IsRegular() || info.Size() > 0 {
+			return fmt.Errorf("Mount path %q of volume %q isn't a directory (%s), move it out of the way to mount the volume", mountPath, vol.name, tnFileKind(info.Mode()))
+		}
+
+		d.logger.Warn("Removing empty file found at volume mount path", logger.Ctx{"volName": vol.name, "path": mountPath})
+
+		err = os.Remove(mountPath)
+		if err != nil {
+			return fmt.Errorf("Failed removing empty file at mount path %q: %w", mountPath, err)
+		}
+	}
+
+	return vol.EnsureMountPath(false)
+}
+
+// tnFileKind describes the type of a file for error messages.
+func tnFileKind(mode fs.FileMode) string {
+	switch {
+	case mode.IsRegular():
+		return "regular file"
+	case mode&fs.ModeSymlink != 0:
+		return "symbolic link"
+	case mode&fs.ModeDevice != 0:
+		return "device"
+	case mode&fs.ModeNamedPipe != 0:
+		return "named pipe"
+	case mode&fs.ModeSocket != 0:
+		return "socket"
+	default:
+		return mode.Type().String()
+	}
+}
+
+// isReadonly returns whether the pool is in read-only mode (truenas.readonly).
+func (d *truenas) isReadonly() bool {
+	return util.IsTrue(d.config["truenas.readonly"])
+}
+
+// checkWritable returns an error if the pool is in read-only mode, for operations which modify the datasets.
+func (d *truenas) checkWritable() error {
+	if d.isReadonly() {
+		return errors.New("Storage pool is read-only (truenas.readonly)")
+	}
+
+	return nil
+}
+
+// tnMergeMountOptions merges two comma separated lists of mount options. Options from overrides replace
+// the options from defaults with the same name (the part before any "=").
+func tnMergeMountOptions(defaults string, overrides string) string {
+	optionName := func(option string) string {
+		name, _, _ := strings.Cut(option, "=")
+		return name
+	}
+
+	overridden := map[string]bool{}
+	for _, option := range strings.Split(overrides, ",") {
+		if option != "" {
+			overridden[optionName(option)] = true
+		}
+	}
+
+	merged := []string{}
+	for _, option := range strings.Split(defaults, ",") {
+		if option != "" && !overridden[optionName(option)] {
+			merged = append(merged, option)
+		}
+	}
+
+	for _, option := range strings.Split(overrides, ",") {
+		if option != "" {
+			merged = append(merged, option)
+		}
+	}
+
+	return strings.Join(merged, ",")
+}
+
+// tnMountHolders returns the processes which have their working directory or an open file below a mount path.
+func tnMountHolders(mountPath string) []string {
+	holders := []string{}
+
+	procDirs, err := filepath.Glob("/proc/[0-9]*")
+	if err != nil {
+		return holders
+	}
+
+	isHeld := func(path string) bool {
+		target, err := os.Readlink(path)
+		if err != nil {
+			return false
+		}
+
+		return target == mountPath || strings.HasPrefix(target, mountPath+"/")
+	}
+
+	for _, procDir := range procDirs {
+		held := isHeld(filepath.Join(procDir, "cwd"))
+		if !held {
+			fds, _ := filepath.Glob(filepath.Join(procDir, "fd", "*"))
+			held = slices.ContainsFunc(fds, isHeld)
+		}
+
+		if !held {
+			continue
+		}
+
+		comm, _ := os.ReadFile(filepath.Join(procDir, "comm"))
+		holders = append(holders, fmt.Sprintf("%s (%s)", filepath.Base(procDir), strings.TrimSpace(string(comm))))
+	}
+
+	return holders
+}
+
+// tnMountsBelow returns the mount points found in mountinfo (in /proc/self/mountinfo format) which are below
+// root, deepest first so that they can be unmounted in order.
+func tnMountsBelow(mountinfo io.Reader, root string) ([]string, error) {
+	root = filepath.Clean(root)
+	mounts := []string{}
+
+	scanner := bufio.NewScanner(mountinfo)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+
+		// Spaces and other special characters are octal escaped.
+		mountPoint, err := strconv.Unquote(`"` + strings.ReplaceAll(fields[4], `"`, `\"`) + `"`)
+		if err != nil {
+			mountPoint = fields[4]
+		}
+
+		if strings.HasPrefix(filepath.Clean(mountPoint), root+"/") {
+			mounts = append(mounts, mountPoint)
+		}
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(mounts, func(a string, b string) int {
+		return strings.Count(b, "/") - strings.Count(a, "/")
+	})
+
+	return slices.Compact(mounts), nil
+}
+
+// activeMounts returns the mount points currently found below the pool's mount path.
+func (d *truenas) activeMounts() ([]string, error) {
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = f.Close() }()
+
+	return tnMountsBelow(f, GetPoolMountPath(d.name))
+}
+
+// tnVolumeContentType works out the content type, volume name and image filesystem of a zvol found
+// below the volume type dataset, using the dataset name suffixes and its incus:content_type property.
+func tnVolumeContentType(volType VolumeType, volName string, incusContentType string) (ContentType, string, string) {
+	contentType := ContentTypeFS
+	volFs := ""
+
+	if volType == VolumeTypeCustom && strings.HasSuffix(volName, zfsISOVolSuffix) {
+		contentType = ContentTypeISO
+		volName = strings.TrimSuffix(volName, zfsISOVolSuffix)
+	} else if volType == VolumeTypeVM || (volType == VolumeTypeImage && strings.HasSuffix(volName, zfsBlockVolSuffix)) {
+		contentType = ContentTypeBlock
+		volName = strings.TrimSuffix(volName, zfsBlockVolSuffix)
+	}
+
+	// FS images have the FS encoded after a _ separator
+	if volType == VolumeTypeImage && strings.Contains(volName, "_") {
+		volName, volFs, _ = strings.Cut(volName, "_")
+	}
+
+	// Get correct content type from incus:content_type property.
+	if incusContentType != "-" && incusContentType != "" {
+		contentType = ContentType(incusContentType)
+	}
+
+	return contentType, volName, volFs
+}
+
+// tnParseDatasetProperty parses a "name,<property>" dataset listing (such as "name,incus:content_type") into the
+// property value of each dataset.
+func tnParseDatasetProperty(out string) (map[string]string, error) {
+	values := map[string]string{}
+
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		name, value, found := strings.Cut(line, "\t")
+		if !found {
+			return nil, fmt.Errorf("Unexpected volume line %q", line)
+		}
+
+		values[name] = value
+	}
+
+	return values, nil
+}
+
+// tnNestedPoolConflict returns an error if the dataset is below a dataset managed by Incus, or if it contains one
+// without being managed itself. The managedBy argument maps datasets to their "managedby" property. A dataset
+// that contains managed datasets is an existing pool dataset being reused if it's managed itself, if the managed
+// datasets are in the volume type datasets of the pool layout, or if reusing was forced.
+func tnNestedPoolConflict(dataset string, managedBy map[string]string, forceReuse bool) error {
+	names := slices.Sorted(maps.Keys(managedBy))
+	for _, name := range names {
+		if managedBy[name] != tnDefaultSettings["managedby"] {
+			continue
+		}
+
+		if strings.HasPrefix(dataset, name+"/") {
+			return fmt.Errorf("Dataset %q is inside dataset %q which is managed by Incus", dataset, name)
+		}
+
+		if !strings.HasPrefix(name, dataset+"/") || forceReuse || managedBy[dataset] == tnDefaultSettings["managedby"] {
+			continue
+		}
+
+		top, _, _ := strings.Cut(strings.TrimPrefix(name, dataset+"/"), "/")
+		_, isTypeDir := BaseDirectories[VolumeType(top)]
+		if isTypeDir || top == "deleted" {
+			continue
+		}
+
+		return fmt.Errorf("Dataset %q contains dataset %q which is managed by Incus", dataset, name)
+	}
+
+	return nil
+}
+
+// tnContentTypeMismatches returns the sorted datasets whose current incus:content_type doesn't match the expected
+// one. Unset properties are reported as "-" and always mismatch.
+func tnContentTypeMismatches(current map[string]string, expected map[string]ContentType) []string {
+	datasets := []string{}
+	for dataset, contentType := range expected {
+		value, found := current[dataset]
+		if found && value != string(contentType) {
+			datasets = append(datasets, dataset)
+		}
+	}
+
+	slices.Sort(datasets)
+
+	return datasets
+}
+
+// tnValidateExpiry validates an expiry expression such as "7d" or "1w 2d".
+func tnValidateExpiry(value string) error {
+	_, err := internalInstance.GetExpiry(time.Time{}, value)
+	return err
+}
+
+// retainedDatasetName returns a name below the deleted path for keeping the volume's dataset. It's made of the
+// dataset's name and the current time, so that deleting a volume again within the retention period doesn't clash.
+func (d *truenas) retainedDatasetName(vol Volume) string {
+	name := fmt.Sprintf("%s_%s", filepath.Base(d.dataset(vol, false)), time.Now().UTC().Format(tnRetainedTimeFormat))
+
+	return filepath.Join(d.config["truenas.dataset"], "deleted", string(vol.volType), name)
+}
+
+// retainDeletedDataset moves a deleted dataset under the deleted path, recording when and from where
+// it was deleted so that it can be recovered until purged by purgeDeletedDatasets.
+func (d *truenas) retainDeletedDataset(dataset string, deletedDataset string) error {
+	err := d.setDatasetProperties(dataset, fmt.Sprintf("user-props=%s=%s", tnPropDeletedAt, time.Now().UTC().Format(time.RFC3339)), fmt.Sprintf("user-props=%s=%s", tnPropDeletedFrom, dataset))
+	if err != nil {
+		return err
+	}
+
+	return d.renameDataset(dataset, deletedDataset, false)
+}
+
+// deletedDatasets returns the volume datasets (deleted/<type>/<name>) below the pool's deleted path.
+func (d *truenas) deletedDatasets() ([]string, error) {
+	deletedPath := filepath.Join(d.config["truenas.dataset"], "deleted")
+	entries, err := d.getDatasets(deletedPath, "filesystem,volume")
+	if err != nil {
+		return nil, err
+	}
+
+	datasets := []string{}
+	for _, entry := range entries {
+		if strings.Count(strings.Trim(entry, "/"), "/") == 1 {
+			datasets = append(datasets, filepath.Join(deletedPath, strings.Trim(entry, "/")))
+		}
+	}
+
+	return datasets, nil
+}
+
+// purgeDeletedDatasets deletes the retained datasets whose truenas.delete_retention period has expired.
+func (d *truenas) purgeDeletedDatasets() error {
+	retention := d.config["truenas.delete_retention"]
+	if retention == "" {
+		return nil
+	}
+
+	datasets, err := d.deletedDatasets()
+	if err != nil {
+		return err
+	}
+
+	if len(datasets) == 0 {
+		return nil
+	}
+
+	props, err := d.getDatasetsAndProperties(datasets, []string{tnPropDeletedAt})
+	if err != nil {
+		return err
+	}
+
+	for dataset, values := range props {
+		deletedAt, err := time.Parse(time.RFC3339, values[tnPropDeletedAt])
+		if err != nil {
+			// Not retained by truenas.delete_retention.
+			continue
+		}
+
+		expiry, err := internalInstance.GetExpiry(deletedAt, retention)
+		if err != nil {
+			return err
+		}
+
+		if time.Now().Before(expiry) {
+			continue
+		}
+
+		// Datasets which are still the origin of a clone are cleaned up with their last clone.
+		clones, err := d.getClones(dataset)
+		if err != nil {
+			return err
+		}
+
+		if len(clones) > 0 {
+			continue
+		}
+
+		err = d.deleteDatasetRecursive(dataset)
+		if err != nil {
+			return err
+		}
+
+		d.logger.Debug("Purged expired deleted dataset", logger.Ctx{"dataset": dataset, "deletedAt": deletedAt})
+	}
+
+	return nil
+}
+
+// tnDatasetSuffixLen returns the length of the longest suffix the driver appends to the pool dataset for a
+// volume and snapshot with names of the given lengths: "/deleted/virtual-machines/<name>.block@<prefix><snapshot>".
+func tnDatasetSuffixLen(snapPrefix string, volNameLen int, snapNameLen int) int {
+	return len("/deleted/") + len(VolumeTypeVM) + len("/") + volNameLen + len(zfsBlockVolSuffix) + len("@") + len(snapPrefix) + snapNameLen
+}
+
+// tnValidateDatasetNameLength checks that the pool dataset leaves room for at least single character volume
+// and snapshot names within the ZFS dataset name length limit.
+func tnValidateDatasetNameLength(dataset string, snapPrefix string) error {
+	if len(dataset)+tnDatasetSuffixLen(snapPrefix, 1, 1) > tnMaxDatasetNameLen {
+		return fmt.Errorf("Dataset name %q is too long to hold volumes (ZFS limits dataset names to %d characters)", dataset, tnMaxDatasetNameLen)
+	}
+
+	return nil
+}
+
+// snapshotPrefix returns the prefix used for the names of Incus snapshots on the remote datasets.
+func (d *truenas) snapshotPrefix() string {
+	prefix := d.config["truenas.snapshot_prefix"]
+	if prefix == "" {
+		return tnDefaultSnapPrefix
+	}
+
+	return prefix
+}
+
+// tnValidateSnapshotPrefix checks that a snapshot prefix is a valid ZFS snapshot name component which
+// can't be confused with the internal snapshots created by the driver.
+func tnValidateSnapshotPrefix(value string) error {
+	for _, r := range value {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') && !strings.ContainsRune("_-.:", r) {
+			return fmt.Errorf("Invalid character %q in snapshot prefix", r)
+		}
+	}
+
+	// Prevent collisions with the internal snapshots used for deletions and copies.
+	for _, reserved := range []string{"deleted-", "copy-"} {
+		if strings.HasPrefix(value, reserved) || strings.HasPrefix(reserved, value) {
+			return fmt.Errorf("Snapshot prefix %q collides with reserved snapshot prefix %q", value, reserved)
+		}
+	}
+
+	return nil
+}
+
+// tnDatasetDefaultKeys are the dataset properties which can be overridden with truenas.dataset_defaults.
+var tnDatasetDefaultKeys = []string{"aclmode", "acltype", "atime", "exec"}
+
+// tnDatasetDefaults returns the properties applied to the pool's datasets, which are the driver defaults
+// overridden by the comma separated list of property=value pairs from truenas.dataset_defaults.
+func tnDatasetDefaults(overrides string) map[string]string {
+	defaults := maps.Clone(tnDefaultSettings)
+	for _, override := range util.SplitNTrimSpace(overrides, ",", -1, true) {
+		key, value, _ := strings.Cut(override, "=")
+		defaults[key] = value
+	}
+
+	return defaults
+}
+
+// tnValidateDatasetDefaults checks that truenas.dataset_defaults only overrides supported dataset properties.
+func tnValidateDatasetDefaults(value string) error {
+	for _, override := range util.SplitNTrimSpace(value, ",", -1, true) {
+		key, propValue, found := strings.Cut(override, "=")
+		if !found || propValue == "" {
+			return fmt.Errorf("Invalid dataset default %q, must be of the form property=value", override)
+		}
+
+		if !slices.Contains(tnDatasetDefaultKeys, key) {
+			return fmt.Errorf("Unsupported dataset default %q, must be one of: %s", key, strings.Join(tnDatasetDefaultKeys, ", "))
+		}
+	}
+
+	return nil
+}
+
+// tnCanRestoreImage returns whether a deleted cached image volume of cachedSize bytes can be restored for a
+// pool whose volumes are poolSize bytes, according to the truenas.image_restore mode.
+// A cached image smaller than the pool's volumes is fine to restore ("grow") as the volumes cloned from it are
+// grown on creation, while those cloned from a larger one can't be shrunk and get unpacked instead ("always").
+func tnCanRestoreImage(mode string, cachedSize int64, poolSize int64) bool {
+	switch mode {
+	case "always":
+		return true
+	case "grow":
+		return cachedSize <= poolSize
+	default:
+		return cachedSize == poolSize
+	}
+}
+
+// tnParseUserProperties parses truenas.user_properties, a comma or newline separated list of
+// namespace:key=value ZFS user properties.
+func tnParseUserProperties(value string) (map[string]string, error) {
+	props := map[string]string{}
+	for _, entry := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == '\n' }) {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		key, propValue, found := strings.Cut(entry, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("Invalid user property %q, must be of the form namespace:key=value", entry)
+		}
+
+		// ZFS tells user properties apart from native ones by the colon in their name.
+		if !strings.Contains(key, ":") {
+			return nil, fmt.Errorf("User property %q must use a namespace (namespace:key)", key)
+		}
+
+		if strings.HasPrefix(key, "incus:") {
+			return nil, fmt.Errorf("User property %q uses the reserved \"incus\" namespace", key)
+		}
+
+		for _, r := range key {
+			if !(r >= 'a' && r <= 'z') && !(r >= '0' && r <= '9') && !strings.ContainsRune(":-._", r) {
+				return nil, fmt.Errorf("User property %q may only contain lowercase letters, digits, \":\", \"-\", \".\" and \"_\"", key)
+			}
+		}
+
+		props[key] = strings.TrimSpace(propValue)
+	}
+
+	return props, nil
+}
+
+// tnValidateUserProperties checks that truenas.user_properties only contains valid user properties.
+func tnValidateUserProperties(value string) error {
+	_, err := tnParseUserProperties(value)
+	return err
+}
+
+// tnValidateDatasetParent checks that truenas.parent is a dataset path within the pool dataset which
+// doesn't overlap with the datasets managed by Incus.
+func tnValidateDatasetParent(value string) error {
+	if strings.HasPrefix(value, "/") || strings.HasSuffix(value, "/") {
+		return errors.New("Parent must be a relative dataset path without leading or trailing slashes")
+	}
+
+	for i, part := range strings.Split(value, "/") {
+		if part == "" || part == "." || part == ".." {
+			return fmt.Errorf("Invalid path component %q", part)
+		}
+
+		for _, r := range part {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("_-.:", r) {
+				return fmt.Errorf("Invalid character %q in path component %q", r, part)
+			}
+		}
+
+		_, isTypeDir := BaseDirectories[VolumeType(part)]
+		if i == 0 && (part == "deleted" || isTypeDir) {
+			return fmt.Errorf("Parent cannot be within the %q dataset managed by Incus", part)
+		}
+	}
+
+	return nil
+}
+
+// userPropertyOptions returns the dataset options setting the user properties configured on the volume.
+func (d *truenas) userPropertyOptions(vol Volume) []string {
+	props, err := tnParseUserProperties(vol.ExpandedConfig("truenas.user_properties"))
+	if err != nil {
+		// The config has been validated already.
+		return nil
+	}
+
+	opts := make([]string, 0, len(props))
+	for _, key := range slices.Sorted(maps.Keys(props)) {
+		opts = append(opts, fmt.Sprintf("user-props=%s=%s", key, props[key]))
+	}
+
+	return opts
+}
+
+// tnSyncModes are the values of the ZFS sync property accepted by truenas.sync.
+var tnSyncModes = []string{"standard", "always", "disabled"}
+
+// tnVolumeProperties maps the volume keys setting native ZFS properties on the volume's datasets to the
+// property name.
+var tnVolumeProperties = map[string]string{
+	"truenas.compression": "compression",
+	"truenas.sync":        "sync",
+}
+
+// tnInheritValue is the property value making the TrueNAS host reset a dataset property to the one it inherits.
+const tnInheritValue = "inherit"
+
+// tnValidateCompression checks that truenas.compression is a ZFS compression algorithm, with its level if any.
+func tnValidateCompression(value string) error {
+	if slices.Contains([]string{"on", "off", "lz4", "lzjb", "zle", "gzip", "zstd", "zstd-fast"}, value) {
+		return nil
+	}
+
+	algorithm, levelStr, found := strings.Cut(value, "-")
+	if algorithm == "zstd" && strings.HasPrefix(levelStr, "fast-") {
+		algorithm, levelStr = "zstd-fast", strings.TrimPrefix(levelStr, "fast-")
+	}
+
+	level, err := strconv.Atoi(levelStr)
+	if found && err == nil {
+		switch algorithm {
+		case "gzip":
+			if level >= 1 && level <= 9 {
+				return nil
+			}
+
+		case "zstd":
+			if level >= 1 && level <= 19 {
+				return nil
+			}
+
+		case "zstd-fast":
+			if level >= 1 && (level <= 10 || (level <= 100 && level%10 == 0) || level == 500 || level == 1000) {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("Invalid compression %q, must be one of on, off, lz4, lzjb, zle, gzip[-1-9], zstd[-1-19] or zstd-fast[-level]", value)
+}
+
+// volumePropertyOptions returns the dataset options setting the native ZFS properties configured for the volume.
+func (d *truenas) volumePropertyOptions(vol Volume) []string {
+	opts := []string{}
+	for _, key := range slices.Sorted(maps.Keys(tnVolumeProperties)) {
+		value := vol.ExpandedConfig(key)
+		if value != "" {
+			opts = append(opts, fmt.Sprintf("%s=%s", tnVolumeProperties[key], value))
+		}
+	}
+
+	return opts
+}
+
+// updateVolumeProperty applies a new value of one of the tnVolumeProperties keys to the volume's datasets. When
+// the key is cleared, the pool's volume.* default is applied or else the property is inherited again.
+func (d *truenas) updateVolumeProperty(vol Volume, key string, value string) error {
+	prop := tnVolumeProperties[key]
+
+	if value == "" {
+		value = vol.poolConfig["volume."+key]
+	}
+
+	if value == "" {
+		value = tnInheritValue
+	}
+
+	datasets := []string{d.dataset(vol, false)}
+	if vol.IsVMBlock() {
+		datasets = append(datasets, d.dataset(vol.NewVMBlockFilesystemVolume(), false))
+	}
+
+	for _, dataset := range datasets {
+		err := d.setDatasetProperties(dataset, fmt.Sprintf("%s=%s", prop, value))
+		if err != nil {
+			return fmt.Errorf("Failed setting %s on %q: %w", prop, dataset, err)
+		}
+	}
+
+	return nil
+}
+
+// tnCompressionInfo describes the compression of a dataset from its "compression" and "compressratio" properties.
+func tnCompressionInfo(compression string, ratio string) map[string]string {
+	info := map[string]string{}
+	if compression != "" && compression != "-" {
+		info["compression"] = compression
+	}
+
+	if ratio != "" && ratio != "-" {
+		info["compression_ratio"] = strings.TrimSuffix(ratio, "x") + "x"
+	}
+
+	return info
+}
+
+// updateUserProperties applies the changes between two truenas.user_properties values to the volume's
+// datasets. Properties dropped from the list are cleared.
+func (d *truenas) updateUserProperties(vol Volume, oldValue string, newValue string) error {
+	oldProps, err := tnParseUserProperties(oldValue)
+	if err != nil {
+		return err
+	}
+
+	newProps, err := tnParseUserProperties(newValue)
+	if err != nil {
+		return err
+	}
+
+	opts := []string{}
+	for _, key := range slices.Sorted(maps.Keys(oldProps)) {
+		_, ok := newProps[key]
+		if !ok {
+			opts = append(opts, fmt.Sprintf("user-props=%s=", key))
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(newProps)) {
+		value, ok := oldProps[key]
+		if !ok || value != newProps[key] {
+			opts = append(opts, fmt.Sprintf("user-props=%s=%s", key, newProps[key]))
+		}
+	}
+
+	if len(opts) == 0 {
+		return nil
+	}
+
+	datasets := []string{d.dataset(vol, false)}
+	if vol.IsVMBlock() {
+		datasets = append(datasets, d.dataset(vol.NewVMBlockFilesystemVolume(), false))
+	}
+
+	for _, dataset := range datasets {
+		err := d.setDatasetProperties(dataset, opts...)
+		if err != nil {
+			return fmt.Errorf("Failed setting user properties on %q: %w", dataset, err)
+		}
+	}
+
+	return nil
+}
+
+// reapplyDatasetDefaults applies the properties that changed between oldDefaults and newDefaults to the pool
+// dataset and to all of the datasets below it which still follow the old defaults. Datasets which have a
+// different value set (per-volume overrides) are left untouched.
+func (d *truenas) reapplyDatasetDefaults(oldDefaults map[string]string, newDefaults map[string]string) error {
+	changedKeys := []string{}
+	for _, key := range tnDatasetDefaultKeys {
+		if oldDefaults[key] != newDefaults[key] {
+			changedKeys = append(changedKeys, key)
+		}
+	}
+
+	if len(changedKeys) == 0 {
+		return nil
+	}
+
+	poolDataset := d.config["truenas.dataset"]
+
+	poolArgs := make([]string, 0, len(changedKeys))
+	for _, key := range changedKeys {
+		poolArgs = append(poolArgs, fmt.Sprintf("%s=%s", key, newDefaults[key]))
+	}
+
+	err := d.setDatasetProperties(poolDataset, poolArgs...)
+	if err != nil {
+		return err
+	}
+
+	// Only file systems carry these properties, volumes (zvols) don't.
+	entries, err := d.getDatasets(poolDataset, "filesystem")
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	datasets := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		datasets = append(datasets, filepath.Join(poolDataset, strings.Trim(entry, "/")))
+	}
+
+	props, err := d.getDatasetsAndProperties(datasets, changedKeys)
+	if err != nil {
+		return err
+	}
+
+	updated := []string{}
+	overridden := []string{}
+	for _, dataset := range datasets {
+		values, ok := props[dataset]
+		if !ok {
+			continue
+		}
 
-	if snapName != "" {
-		if deleted {
-			name = fmt.Sprintf("%s@deleted-%s", name, uuid.New().String())
-		} else {
-			name = fmt.Sprintf("%s@snapshot-%s", name, snapName)
+		args := []string{}
+		for _, key := range changedKeys {
+			switch values[key] {
+			case newDefaults[key]:
+				// Already inherited from the pool dataset.
+			case oldDefaults[key]:
+				args = append(args, fmt.Sprintf("%s=%s", key, newDefaults[key]))
+			default:
+				overridden = append(overridden, fmt.Sprintf("%s (%s=%s)", dataset, key, values[key]))
+			}
 		}
-	} else if deleted {
-		if vol.volType != VolumeTypeImage {
-			name = uuid.New().String()
+
+		if len(args) == 0 {
+			continue
 		}
 
-		return filepath.Join(d.config["truenas.dataset"], "deleted", string(vol.volType), name)
+		err := d.setDatasetProperties(dataset, args...)
+		if err != nil {
+			return fmt.Errorf("Failed applying dataset defaults to %q: %w", dataset, err)
+		}
+
+		updated = append(updated, dataset)
 	}
 
-	return filepath.Join(d.config["truenas.dataset"], string(vol.volType), name)
+	d.logger.Info("Applied dataset defaults", logger.Ctx{"properties": poolArgs, "updated": updated, "overridden": overridden})
+
+	return nil
 }
 
 // runTool runs the truenas control tool with the supplied arguments, whilst applying the global flags as appropriate.
@@ -77,6 +946,9 @@ func (d *truenas) runTool(args ...string) (string, error) {
 
 	args = append(baseArgs, args...)
 
+	release := d.acquireToolSlot()
+	defer release()
+
 	out, err := subprocess.RunCommand(tnToolName, args...)
 
 	if err != nil && strings.Contains(err.Error(), "Post \"http://unix/tnc-daemon\": EOF)") {
@@ -87,7 +959,54 @@ func (d *truenas) runTool(args ...string) (string, error) {
 	}
 
 	// will allow us to prepend args
-	return out, err
+	return out, tnClassifyToolError(err)
+}
+
+// acquireToolSlot waits until the pool is below truenas.max_concurrent_ops in-flight tool invocations and
+// returns the function releasing the slot. Slots are only held for a single invocation, so nested operations
+// can't deadlock on them.
+func (d *truenas) acquireToolSlot() func() {
+	limit, err := strconv.Atoi(d.config["truenas.max_concurrent_ops"])
+	if err != nil || limit <= 0 {
+		return func() {}
+	}
+
+	tnToolSlotsMu.Lock()
+	slots, ok := tnToolSlots[d.name]
+	if !ok || cap(slots) != limit {
+		// Calls holding a slot of a previous limit release it on their own channel.
+		slots = make(chan struct{}, limit)
+		tnToolSlots[d.name] = slots
+	}
+
+	tnToolSlotsMu.Unlock()
+
+	slots <- struct{}{}
+	return func() { <-slots }
+}
+
+// tnClassifyToolError wraps err with ErrTrueNASUnreachable or ErrTrueNASAuth when the tool output indicates a
+// connection or credential problem, allowing callers to use errors.Is to decide whether to retry or fail fast.
+func tnClassifyToolError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	msg := strings.ToLower(err.Error())
+
+	for _, pattern := range []string{"unauthorized", "forbidden", "not authenticated", "enotauthenticated", "authentication failed", "invalid api key"} {
+		if strings.Contains(msg, pattern) {
+			return fmt.Errorf("%w: %w", ErrTrueNASAuth, err)
+		}
+	}
+
+	for _, pattern := range []string{"connection refused", "no route to host", "network is unreachable", "no such host", "i/o timeout", "connection timed out", "connection reset"} {
+		if strings.Contains(msg, pattern) {
+			return fmt.Errorf("%w: %w", ErrTrueNASUnreachable, err)
+		}
+	}
+
+	return err
 }
 
 // runIscsiCmd runs the supplied args against the tools `share iscsi` command whilst applying the appropriate iscsi global flags.
@@ -163,7 +1082,12 @@ func (d *truenas) getDatasetOrSnapshot(dataset string) string {
 func (d *truenas) datasetExists(dataset string) (bool, error) {
 	out, err := d.runTool(d.getDatasetOrSnapshot(dataset), "list", "--no-headers", "-o", "name", dataset)
 	if err != nil {
-		return false, nil // TODO: need to check if tool returns errors for bad connections, vs not-found. Ie, this occurs when recovering with a bad API key or HOST.
+		// Connection and credential problems must not be mistaken for a missing dataset.
+		if errors.Is(err, ErrTrueNASUnreachable) || errors.Is(err, ErrTrueNASAuth) {
+			return false, err
+		}
+
+		return false, nil
 	}
 
 	return strings.TrimSpace(out) == dataset, nil
@@ -323,13 +1247,18 @@ func (d *truenas) cloneSnapshot(srcSnapshot string, destDataset string) error {
 
 // createSnapshot take a recursive snapshot of dataset@snapname, and optionally delete the old snapshot first.
 func (d *truenas) createSnapshot(snapName string, deleteFirst bool) error {
+	return d.createSnapshots([]string{snapName}, deleteFirst)
+}
+
+// createSnapshots takes recursive snapshots of one or more dataset@snapname in a single atomic call, and optionally delete the old snapshots first.
+func (d *truenas) createSnapshots(snapNames []string, deleteFirst bool) error {
 	args := []string{"snapshot", "create", "-r"}
 
 	if deleteFirst {
 		args = append(args, "--delete")
 	}
 
-	args = append(args, snapName)
+	args = append(args, snapNames...)
 
 	// Make the snapshot.
 	out, err := d.runTool(args...)
@@ -380,6 +1309,81 @@ func (d *truenas) createVolume(dataset string, size int64, options ...string) er
 	return nil
 }
 
+// datasetOwner returns the project and instance names a volume belongs to.
+// The instance name is empty for volumes not owned by an instance.
+func (d *truenas) datasetOwner(vol Volume) (string, string) {
+	name, _, _ := api.GetParentAndSnapshotName(vol.name)
+
+	switch vol.volType {
+	case VolumeTypeContainer, VolumeTypeVM:
+		return project.InstanceParts(name)
+	case VolumeTypeCustom:
+		projectName, _ := project.StorageVolumeParts(name)
+		return projectName, ""
+	}
+
+	return "", ""
+}
+
+// creationMetadataOptions returns the user properties to stamp on a newly created volume dataset.
+func (d *truenas) creationMetadataOptions(vol Volume) []string {
+	projectName, instanceName := d.datasetOwner(vol)
+
+	opts := []string{fmt.Sprintf("user-props=%s=%s", tnPropCreatedAt, time.Now().UTC().Format(time.RFC3339))}
+
+	if vol.contentType == ContentTypeFS {
+		opts = append(opts, fmt.Sprintf("user-props=%s=%s", tnPropBlockFilesystem, vol.ConfigBlockFilesystem()))
+	}
+
+	if projectName != "" {
+		opts = append(opts, fmt.Sprintf("user-props=%s=%s", tnPropProject, projectName))
+	}
+
+	if instanceName != "" {
+		opts = append(opts, fmt.Sprintf("user-props=%s=%s", tnPropInstance, instanceName))
+	}
+
+	if vol.volType == VolumeTypeCustom && vol.config["truenas.parent"] != "" {
+		opts = append(opts, fmt.Sprintf("user-props=%s=%s", tnPropParent, vol.config["truenas.parent"]))
+	}
+
+	return opts
+}
+
+// getDatasetMetadata returns the creation metadata recorded on a dataset.
+func (d *truenas) getDatasetMetadata(dataset string) (map[string]string, error) {
+	props, err := d.getDatasetProperties(dataset, []string{tnPropCreatedAt, tnPropProject, tnPropInstance})
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := map[string]string{}
+	for k, v := range props {
+		// Unset user properties are reported as "-".
+		if v == "" || v == "-" {
+			continue
+		}
+
+		metadata[k] = v
+	}
+
+	return metadata, nil
+}
+
+// tnIscsiProbeTTL is how long the result of an iSCSI service probe is reused for a pool.
+const tnIscsiProbeTTL = time.Minute
+
+type tnIscsiProbe struct {
+	err     error
+	expires time.Time
+}
+
+// Result of the last iSCSI service probe of each pool, as the driver is loaded again for most operations.
+var (
+	tnIscsiProbes   = map[string]tnIscsiProbe{}
+	tnIscsiProbesMu sync.Mutex
+)
+
 func (d *truenas) verifyIscsiFunctionality(ensureSetup bool) error {
 	args := []string{"--parsable"}
 
@@ -388,6 +1392,12 @@ func (d *truenas) verifyIscsiFunctionality(ensureSetup bool) error {
 	}
 
 	_, err := d.runIscsiCmd("test", args...)
+
+	// Record the result so that the reported availability follows the latest probe.
+	tnIscsiProbesMu.Lock()
+	tnIscsiProbes[d.name] = tnIscsiProbe{err: err, expires: time.Now().Add(tnIscsiProbeTTL)}
+	tnIscsiProbesMu.Unlock()
+
 	if err != nil {
 		return err
 	}
@@ -395,6 +1405,19 @@ func (d *truenas) verifyIscsiFunctionality(ensureSetup bool) error {
 	return nil
 }
 
+// cachedIscsiFunctionality returns the result of the pool's last iSCSI service probe, probing again once it expired.
+func (d *truenas) cachedIscsiFunctionality() error {
+	tnIscsiProbesMu.Lock()
+	probe, ok := tnIscsiProbes[d.name]
+	tnIscsiProbesMu.Unlock()
+
+	if ok && time.Now().Before(probe.expires) {
+		return probe.err
+	}
+
+	return d.verifyIscsiFunctionality(false)
+}
+
 func (d *truenas) createIscsiShare(dataset string, readonly bool) error {
 	args := []string{}
 
@@ -429,6 +1452,20 @@ func (d *truenas) deleteIscsiShare(dataset string) error {
 	return nil
 }
 
+// unshareImageDataset removes the iSCSI share of a filled image dataset unless truenas.image_shares is enabled.
+// Instances are cloned from the image's read-only snapshot, so the share is only needed when the image itself is
+// activated again, which re-creates it on demand. Failures are only logged as the image is usable either way.
+func (d *truenas) unshareImageDataset(dataset string) {
+	if util.IsTrueOrEmpty(d.config["truenas.image_shares"]) {
+		return
+	}
+
+	err := d.deleteIscsiShare(dataset)
+	if err != nil {
+		d.logger.Warn("Failed removing iSCSI share of image volume", logger.Ctx{"dataset": dataset, "err": err})
+	}
+}
+
 // locateIscsiDataset locates a ZFS volume if already active. Returns devpath if activated, "" if not, or an error.
 func (d *truenas) locateIscsiDataset(dataset string) (string, error) {
 	reverter := revert.New()
@@ -567,7 +1604,7 @@ func (d *truenas) tryDeleteBusyDataset(ctx context.Context, dataset string, recu
 			Method call error
 			[EBUSY] Failed to delete dataset: cannot destroy '<dataset>': dataset is busy)
 		*/
-		if !strings.Contains(err.Error(), "[EBUSY]") {
+		if errors.Is(err, ErrTrueNASUnreachable) || errors.Is(err, ErrTrueNASAuth) || !strings.Contains(err.Error(), "[EBUSY]") {
 			return err
 		}
 
@@ -608,6 +1645,53 @@ func (d *truenas) getDatasetProperty(dataset string, key string) (string, error)
 	return strings.TrimSpace(output), nil
 }
 
+// trackDatasetProgress periodically reports the space used by a dataset being filled on the remote host
+// as the progress of the operation. The returned function stops the reporting.
+func (d *truenas) trackDatasetProgress(dataset string, description string, op *operations.Operation) func() {
+	if op == nil {
+		return func() {}
+	}
+
+	tracker := localMigration.ProgressTracker(op, "fs_progress", description)
+	start := time.Now()
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			// The dataset may not exist until the transfer has started.
+			value, err := d.getDatasetProperty(dataset, "used")
+			if err != nil {
+				continue
+			}
+
+			used, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || used <= 0 {
+				continue
+			}
+
+			tracker.Handler(used, int64(float64(used)/time.Since(start).Seconds()))
+		}
+	}()
+
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
 func (d *truenas) getDatasetProperties(dataset string, properties []string) (map[string]string, error) {
 	response, err := d.getDatasetsAndProperties([]string{dataset}, properties)
 	if err != nil {
@@ -805,6 +1889,32 @@ func (d *truenas) setVolsize(dataset string, sizeBytes int64, allowShrink bool)
 	return nil
 }
 
+// rescanBlockDevice makes the iSCSI initiator pick up the new size of a grown zvol, waiting until its block
+// device reports at least sizeBytes.
+func (d *truenas) rescanBlockDevice(devPath string, sizeBytes int64) error {
+	realPath, err := filepath.EvalSymlinks(devPath)
+	if err != nil {
+		return err
+	}
+
+	rescanPath := filepath.Join("/sys/class/block", filepath.Base(realPath), "device", "rescan")
+	err = os.WriteFile(rescanPath, []byte("1"), 0o200)
+	if err != nil {
+		return fmt.Errorf("Failed rescanning block device %q: %w", realPath, err)
+	}
+
+	for range 30 {
+		size, err := BlockDiskSizeBytes(realPath)
+		if err == nil && size >= sizeBytes {
+			return nil
+		}
+
+		time.Sleep(time.Second)
+	}
+
+	return fmt.Errorf("Block device %q didn't grow to %d bytes after rescan", realPath, sizeBytes)
+}
+
 func (d *truenas) getClones(dataset string) ([]string, error) {
 	out, err := d.runTool("snapshot", "list", "--no-headers", "--parsable", "-r", "-o", "clones", dataset)
 	if err != nil {
@@ -828,3 +1938,300 @@ func (d *truenas) getClones(dataset string) ([]string, error) {
 func (d *truenas) randomVolumeName(vol Volume) string {
 	return fmt.Sprintf("%s_%s", vol.name, uuid.New().String())
 }
+
+// tnRateLimiter is a simple token bucket used to throttle a transfer stream to a fixed byte rate.
+type tnRateLimiter struct {
+	mu     sync.Mutex
+	rate   int64 // Bytes per second.
+	tokens int64
+	last   time.Time
+}
+
+// wait blocks until n bytes are allowed to be transferred.
+func (l *tnRateLimiter) wait(n int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Refill the bucket, allowing at most one second worth of burst.
+	now := time.Now()
+	l.tokens += int64(now.Sub(l.last).Seconds() * float64(l.rate))
+	if l.tokens > l.rate {
+		l.tokens = l.rate
+	}
+
+	l.last = now
+	l.tokens -= int64(n)
+
+	// Sleep off any debt, the elapsed time is credited back on the next call.
+	if l.tokens < 0 {
+		time.Sleep(time.Duration(float64(-l.tokens) / float64(l.rate) * float64(time.Second)))
+	}
+}
+
+// tnRateLimitedConn wraps a connection so that both reads and writes are throttled by a shared limiter.
+type tnRateLimitedConn struct {
+	io.ReadWriteCloser
+	limiter *tnRateLimiter
+}
+
+// Read reads from the underlying connection and then waits for the bytes read to be accounted for.
+func (c *tnRateLimitedConn) Read(p []byte) (int, error) {
+	n, err := c.ReadWriteCloser.Read(p)
+	if n > 0 {
+		c.limiter.wait(n)
+	}
+
+	return n, err
+}
+
+// Write waits for the bytes to be accounted for and then writes them to the underlying connection.
+func (c *tnRateLimitedConn) Write(p []byte) (int, error) {
+	c.limiter.wait(len(p))
+
+	return c.ReadWriteCloser.Write(p)
+}
+
+// tnRateLimitedReader wraps a reader so that reads are throttled by a limiter.
+type tnRateLimitedReader struct {
+	io.Reader
+	limiter *tnRateLimiter
+}
+
+// Read reads from the underlying reader and then waits for the bytes read to be accounted for.
+func (r *tnRateLimitedReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if n > 0 {
+		r.limiter.wait(n)
+	}
+
+	return n, err
+}
+
+// transferLimiter returns a new rate limiter for a transfer when "truenas.transfer_limit" is set on the pool,
+// or nil otherwise.
+func (d *truenas) transferLimiter() (*tnRateLimiter, error) {
+	if d.config["truenas.transfer_limit"] == "" {
+		return nil, nil
+	}
+
+	rate, err := units.ParseByteSizeString(d.config["truenas.transfer_limit"])
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing truenas.transfer_limit: %w", err)
+	}
+
+	if rate <= 0 {
+		return nil, nil
+	}
+
+	return &tnRateLimiter{rate: rate, tokens: rate, last: time.Now()}, nil
+}
+
+// throttleConn wraps conn with a rate limiter when "truenas.transfer_limit" is set on the pool.
+func (d *truenas) throttleConn(conn io.ReadWriteCloser) (io.ReadWriteCloser, error) {
+	limiter, err := d.transferLimiter()
+	if err != nil {
+		return nil, err
+	}
+
+	if limiter == nil {
+		return conn, nil
+	}
+
+	return &tnRateLimitedConn{ReadWriteCloser: conn, limiter: limiter}, nil
+}
+
+// tnCloneInfo describes the clone status of a dataset from its "origin" property, which is "-" for
+// datasets that aren't clones.
+func tnCloneInfo(origin string) map[string]string {
+	if origin == "" || origin == "-" {
+		return map[string]string{"clone": "false"}
+	}
+
+	return map[string]string{"clone": "true", "origin": origin}
+}
+
+// tnClassifyDeletedDataset classifies a dataset below the deleted path from its incus:deleted_at property, whether
+// it's still the origin of clones and the pool's truenas.delete_retention. It returns "retained" for datasets kept
+// for recovery, "expired" for those to be purged on the next mount, "orphaned" for those which nothing will clean
+// up and an empty string for datasets only kept as the origin of clones.
+func tnClassifyDeletedDataset(deletedAt string, hasClones bool, retention string, now time.Time) string {
+	deletedTime, err := time.Parse(time.RFC3339, deletedAt)
+	if err != nil {
+		// Not retained, only kept while clones depend on it.
+		if hasClones {
+			return ""
+		}
+
+		return "orphaned"
+	}
+
+	if hasClones {
+		return "retained"
+	}
+
+	// Retained datasets are only purged while truenas.delete_retention is set.
+	if retention == "" {
+		return "orphaned"
+	}
+
+	expiry, err := internalInstance.GetExpiry(deletedTime, retention)
+	if err != nil || !now.Before(expiry) {
+		return "expired"
+	}
+
+	return "retained"
+}
+
+// tnStaleTemporaryDataset returns whether a dataset is a temporary snapshot clone (see getTempSnapshotVolName)
+// left behind by an earlier Incus process on this server.
+func tnStaleTemporaryDataset(dataset string, serverName string, pid int) bool {
+	name, found := strings.CutSuffix(dataset, tmpVolSuffix)
+	if !found {
+		return false
+	}
+
+	owner, pidStr, found := strings.Cut(name[strings.LastIndex(name, "_")+1:], "-")
+	if !found || owner != serverName {
+		return false
+	}
+
+	return pidStr != strconv.Itoa(pid)
+}
+
+// Number of most recently created datasets compared by tnClockSkew, and the skew above which a warning is logged.
+const (
+	tnClockSkewSamples   = 5
+	tnClockSkewThreshold = 5 * time.Minute
+)
+
+// tnClockSkew estimates how far the TrueNAS host clock is ahead of the local one, from `list` output of the
+// name, creation (seconds since the epoch, from the host clock) and incus:created_at (RFC3339, from the local
+// clock) of volume datasets. Datasets filled by replication only get their creation metadata once the data is
+// copied, so the smallest skew among the most recently created datasets is used. Returns false if no dataset
+// carries both timestamps.
+func tnClockSkew(out string) (time.Duration, bool) {
+	type sample struct {
+		createdAt time.Time
+		skew      time.Duration
+	}
+
+	samples := []sample{}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Split(strings.TrimSpace(line), "\t")
+		if len(fields) != 3 {
+			continue
+		}
+
+		creation, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		createdAt, err := time.Parse(time.RFC3339, fields[2])
+		if err != nil {
+			continue
+		}
+
+		samples = append(samples, sample{createdAt: createdAt, skew: time.Unix(creation, 0).Sub(createdAt)})
+	}
+
+	if len(samples) == 0 {
+		return 0, false
+	}
+
+	slices.SortFunc(samples, func(a sample, b sample) int { return b.createdAt.Compare(a.createdAt) })
+
+	skew := samples[0].skew
+	for _, s := range samples[1:min(len(samples), tnClockSkewSamples)] {
+		if s.skew.Abs() < skew.Abs() {
+			skew = s.skew
+		}
+	}
+
+	return skew, true
+}
+
+// checkClockSkew warns when the TrueNAS host clock appears to be skewed relative to the local one, which would
+// make the host's snapshot and dataset timestamps disagree with those recorded by Incus. The skew is estimated
+// from the creation metadata of the most recently created volumes, so that nothing is written to the host.
+func (d *truenas) checkClockSkew() error {
+	out, err := d.runTool("list", "--no-headers", "--parsable", "-o", "name,creation,"+tnPropCreatedAt, "-r", "-t", "volume", d.config["truenas.dataset"])
+	if err != nil {
+		return err
+	}
+
+	skew, ok := tnClockSkew(out)
+	if ok && skew.Abs() > tnClockSkewThreshold {
+		d.logger.Warn("TrueNAS host clock appears skewed relative to this server, check time synchronization on both", logger.Ctx{"host": d.config["truenas.host"], "skew": skew.Round(time.Second)})
+	}
+
+	return nil
+}
+
+// auditDatasets reports the leftover datasets of the pool without changing anything: volumes kept below the
+// deleted path (retained, expired or orphaned) and temporary snapshot clones left behind by earlier processes.
+func (d *truenas) auditDatasets() (map[string]string, error) {
+	report := map[string]string{}
+	found := map[string][]string{}
+
+	deleted, err := d.deletedDatasets()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(deleted) > 0 {
+		props, err := d.getDatasetsAndProperties(deleted, []string{tnPropDeletedAt})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, dataset := range deleted {
+			clones, err := d.getClones(dataset)
+			if err != nil {
+				return nil, err
+			}
+
+			class := tnClassifyDeletedDataset(props[dataset][tnPropDeletedAt], len(clones) > 0, d.config["truenas.delete_retention"], time.Now())
+			if class != "" {
+				found[class] = append(found[class], strings.TrimPrefix(dataset, d.config["truenas.dataset"]+"/"))
+			}
+		}
+	}
+
+	datasets, err := d.getDatasets(d.config["truenas.dataset"], "filesystem,volume")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dataset := range datasets {
+		if tnStaleTemporaryDataset(dataset, d.state.ServerName, os.Getpid()) {
+			found["temporary"] = append(found["temporary"], strings.TrimPrefix(dataset, "/"))
+		}
+	}
+
+	report["deleted_retained"] = strconv.Itoa(len(found["retained"]))
+	for _, class := range []string{"expired", "orphaned"} {
+		if len(found[class]) > 0 {
+			report["deleted_"+class] = strings.Join(found[class], ",")
+		}
+	}
+
+	if len(found["temporary"]) > 0 {
+		report["temporary"] = strings.Join(found["temporary"], ",")
+	}
+
+	return report, nil
+}
+
+// tnFilesystemCandidates returns the filesystems to try mounting a volume whose filesystem couldn't be probed
+// with, starting with the configured one.
+func tnFilesystemCandidates(configured string) []string {
+	fsTypes := []string{configured}
+	for _, fsType := range blockBackedAllowedFilesystems {
+		if fsType != configured {
+			fsTypes = append(fsTypes, fsType)
+		}
+	}
+
+	return fsTypes
+}
@@ -0,0 +1,360 @@
+package drivers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+func Example_truenas_classifyToolError() {
+	tests := []error{
+		errors.New(`Failed to run: truenas_incus_ctl list: exit status 1 (dial tcp 192.0.2.1:443: connect: connection refused)`),
+		errors.New(`Failed to run: truenas_incus_ctl list: exit status 1 (401 Unauthorized)`),
+		errors.New(`Failed to run: truenas_incus_ctl dataset delete: exit status 1 ([EBUSY] dataset is busy)`),
+	}
+
+	for _, test := range tests {
+		err := tnClassifyToolError(test)
+		fmt.Println(errors.Is(err, ErrTrueNASUnreachable), errors.Is(err, ErrTrueNASAuth))
+	}
+
+	// Output: true false
+	// false true
+	// false false
+}
+
+func Example_truenas_validateSnapshotPrefix() {
+	tests := []string{
+		"snapshot-",
+		"incus.",
+		"copy-",
+		"c",
+		"snap@",
+	}
+
+	for _, test := range tests {
+		fmt.Println(test, tnValidateSnapshotPrefix(test))
+	}
+
+	// Output: snapshot- <nil>
+	// incus. <nil>
+	// copy- Snapshot prefix "copy-" collides with reserved snapshot prefix "copy-"
+	// c Snapshot prefix "c" collides with reserved snapshot prefix "copy-"
+	// snap@ Invalid character '@' in snapshot prefix
+}
+
+func Example_truenas_mergeMountOptions() {
+	tests := [][2]string{
+		{"", "discard"},
+		{"noatime", "discard"},
+		{"noatime,commit=60", "discard,commit=30"},
+		{"noatime,discard", "discard"},
+	}
+
+	for _, test := range tests {
+		fmt.Println(tnMergeMountOptions(test[0], test[1]))
+	}
+
+	// Output: discard
+	// noatime,discard
+	// noatime,discard,commit=30
+	// noatime,discard
+}
+
+func Example_truenas_volumeContentType() {
+	tests := [][3]string{
+		{string(VolumeTypeCustom), "media.iso", "iso"},
+		{string(VolumeTypeCustom), "media.iso", "-"},
+		{string(VolumeTypeCustom), "data", "block"},
+		{string(VolumeTypeCustom), "data", "filesystem"},
+		{string(VolumeTypeVM), "v1.block", "-"},
+		{string(VolumeTypeImage), "abc.block", "-"},
+		{string(VolumeTypeImage), "abc_ext4", "-"},
+	}
+
+	for _, test := range tests {
+		contentType, volName, volFs := tnVolumeContentType(VolumeType(test[0]), test[1], test[2])
+		fmt.Printf("%s %s %q\n", contentType, volName, volFs)
+	}
+
+	// Output: iso media ""
+	// iso media ""
+	// block data ""
+	// filesystem data ""
+	// block v1 ""
+	// block abc ""
+	// filesystem abc "ext4"
+}
+
+func Example_truenas_validateDatasetNameLength() {
+	fmt.Println(tnDatasetSuffixLen("snapshot-", 0, 0))
+	fmt.Println(tnDatasetSuffixLen("snapshot-", 63, 63))
+
+	// The longest dataset leaving room for single character volume and snapshot names.
+	dataset := "tank/" + strings.Repeat("a", tnMaxDatasetNameLen-tnDatasetSuffixLen("snapshot-", 1, 1)-len("tank/"))
+	fmt.Println(len(dataset), tnValidateDatasetNameLength(dataset, "snapshot-"))
+	fmt.Println(tnValidateDatasetNameLength(dataset+"a", "snapshot-") != nil)
+
+	// Output: 42
+	// 168
+	// 211 <nil>
+	// true
+}
+
+func Example_truenas_datasetDefaults() {
+	for _, value := range []string{"", "atime=on", "atime=on, exec=off", "atime", "compression=lz4"} {
+		err := tnValidateDatasetDefaults(value)
+		if err != nil {
+			fmt.Printf("%q: %v\n", value, err)
+			continue
+		}
+
+		defaults := tnDatasetDefaults(value)
+		fmt.Printf("%q: atime=%s exec=%s acltype=%s\n", value, defaults["atime"], defaults["exec"], defaults["acltype"])
+	}
+
+	// Output: "": atime=off exec=on acltype=posix
+	// "atime=on": atime=on exec=on acltype=posix
+	// "atime=on, exec=off": atime=on exec=off acltype=posix
+	// "atime": Invalid dataset default "atime", must be of the form property=value
+	// "compression=lz4": Unsupported dataset default "compression", must be one of: aclmode, acltype, atime, exec
+}
+
+func Example_truenas_userProperties() {
+	tests := []string{
+		"",
+		"billing:team=storage, billing:cost_center=42",
+		"com.example:tag=a\ncom.example:owner=ops",
+		"team=storage",
+		"incus:project=default",
+		"billing:Team=storage",
+		"billing:team",
+	}
+
+	for _, value := range tests {
+		props, err := tnParseUserProperties(value)
+		if err != nil {
+			fmt.Printf("%q: %v\n", value, err)
+			continue
+		}
+
+		fmt.Printf("%q: %v\n", value, props)
+	}
+
+	// Output: "": map[]
+	// "billing:team=storage, billing:cost_center=42": map[billing:cost_center:42 billing:team:storage]
+	// "com.example:tag=a\ncom.example:owner=ops": map[com.example:owner:ops com.example:tag:a]
+	// "team=storage": User property "team" must use a namespace (namespace:key)
+	// "incus:project=default": User property "incus:project" uses the reserved "incus" namespace
+	// "billing:Team=storage": User property "billing:Team" may only contain lowercase letters, digits, ":", "-", "." and "_"
+	// "billing:team": Invalid user property "billing:team", must be of the form namespace:key=value
+}
+
+func Example_truenas_canRestoreImage() {
+	for _, mode := range []string{"", "exact", "grow", "always"} {
+		fmt.Printf("%q: %v %v %v\n", mode, tnCanRestoreImage(mode, 10, 10), tnCanRestoreImage(mode, 8, 10), tnCanRestoreImage(mode, 12, 10))
+	}
+
+	// Output: "": true false false
+	// "exact": true false false
+	// "grow": true true false
+	// "always": true true true
+}
+
+func Example_truenas_mountsBelow() {
+	mountinfo := `22 1 8:1 / / rw,relatime - ext4 /dev/sda1 rw
+30 22 0:40 / /var/lib/incus/storage-pools/tank rw - tmpfs tmpfs rw
+31 30 8:16 / /var/lib/incus/storage-pools/tank/containers/c1 rw - ext4 /dev/sdb rw
+32 31 0:41 / /var/lib/incus/storage-pools/tank/containers/c1/rootfs/mnt rw - tmpfs tmpfs rw
+33 30 8:32 / /var/lib/incus/storage-pools/tank/custom/default_my\040vol rw - ext4 /dev/sdc rw
+34 22 8:48 / /var/lib/incus/storage-pools/tank2/custom/default_other rw - ext4 /dev/sdd rw
+`
+
+	mounts, err := tnMountsBelow(strings.NewReader(mountinfo), "/var/lib/incus/storage-pools/tank")
+	fmt.Println(err)
+	for _, mount := range mounts {
+		fmt.Printf("%q\n", mount)
+	}
+
+	// Output: <nil>
+	// "/var/lib/incus/storage-pools/tank/containers/c1/rootfs/mnt"
+	// "/var/lib/incus/storage-pools/tank/containers/c1"
+	// "/var/lib/incus/storage-pools/tank/custom/default_my vol"
+}
+
+func Example_truenas_validateDatasetParent() {
+	tests := []string{
+		"fast",
+		"fast/custom",
+		"tier-2/ssd_pool",
+		"/fast",
+		"fast/",
+		"fast//custom",
+		"fast/../custom",
+		"custom/fast",
+		"deleted",
+		"fast@snap",
+	}
+
+	for _, value := range tests {
+		fmt.Printf("%q: %v\n", value, tnValidateDatasetParent(value))
+	}
+
+	// Output: "fast": <nil>
+	// "fast/custom": <nil>
+	// "tier-2/ssd_pool": <nil>
+	// "/fast": Parent must be a relative dataset path without leading or trailing slashes
+	// "fast/": Parent must be a relative dataset path without leading or trailing slashes
+	// "fast//custom": Invalid path component ""
+	// "fast/../custom": Invalid path component ".."
+	// "custom/fast": Parent cannot be within the "custom" dataset managed by Incus
+	// "deleted": Parent cannot be within the "deleted" dataset managed by Incus
+	// "fast@snap": Invalid character '@' in path component "fast@snap"
+}
+
+func Example_truenas_contentTypeMismatches() {
+	current, err := tnParseDatasetProperty("tank/incus/custom/default_data\tfilesystem\ntank/incus/custom/default_disk\t-\ntank/incus/custom/default_raw\tfilesystem\ntank/incus/custom/default_win.iso\tiso\n")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	expected := map[string]ContentType{
+		"tank/incus/custom/default_data":    ContentTypeFS,
+		"tank/incus/custom/default_disk":    ContentTypeBlock,
+		"tank/incus/custom/default_raw":     ContentTypeBlock,
+		"tank/incus/custom/default_win.iso": ContentTypeISO,
+		"tank/incus/custom/default_gone":    ContentTypeFS,
+	}
+
+	for _, dataset := range tnContentTypeMismatches(current, expected) {
+		fmt.Printf("%s: %s -> %s\n", dataset, current[dataset], expected[dataset])
+	}
+
+	_, err = tnParseDatasetProperty("tank/incus/custom/default_data")
+	fmt.Println(err)
+
+	// Output: tank/incus/custom/default_disk: - -> block
+	// tank/incus/custom/default_raw: filesystem -> block
+	// Unexpected volume line "tank/incus/custom/default_data"
+}
+
+func Example_truenas_cloneInfo() {
+	fmt.Println(tnCloneInfo("-"))
+	fmt.Println(tnCloneInfo("tank/incus/images/0123456789abcdef@readonly"))
+
+	// Output: map[clone:false]
+	// map[clone:true origin:tank/incus/images/0123456789abcdef@readonly]
+}
+
+func Example_truenas_nestedPoolConflict() {
+	managedBy := map[string]string{
+		"tank":                 "-",
+		"tank/incus":           "incus.truenas",
+		"tank/incus/custom":    "incus.truenas",
+		"tank/incus/images":    "incus.truenas",
+		"tank/shared":          "-",
+		"tank/shared/other":    "-",
+		"tank/incus-two":       "-",
+		"tank/incus-two/other": "-",
+		"tank/old":             "-",
+		"tank/old/custom":      "incus.truenas",
+		"tank/old/images":      "incus.truenas",
+	}
+
+	for _, dataset := range []string{"tank/incus", "tank/incus-two", "tank/new", "tank/incus/custom/nested", "tank", "tank/old"} {
+		fmt.Printf("%q: %v\n", dataset, tnNestedPoolConflict(dataset, managedBy, false))
+	}
+
+	fmt.Printf("%q: %v\n", "tank", tnNestedPoolConflict("tank", managedBy, true))
+
+	// Output: "tank/incus": <nil>
+	// "tank/incus-two": <nil>
+	// "tank/new": <nil>
+	// "tank/incus/custom/nested": Dataset "tank/incus/custom/nested" is inside dataset "tank/incus" which is managed by Incus
+	// "tank": Dataset "tank" contains dataset "tank/incus" which is managed by Incus
+	// "tank/old": <nil>
+	// "tank": <nil>
+}
+
+func Example_truenas_classifyDeletedDataset() {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	fmt.Printf("%q\n", tnClassifyDeletedDataset("2026-03-09T12:00:00Z", false, "7d", now))
+	fmt.Printf("%q\n", tnClassifyDeletedDataset("2026-03-01T12:00:00Z", false, "7d", now))
+	fmt.Printf("%q\n", tnClassifyDeletedDataset("2026-03-01T12:00:00Z", true, "7d", now))
+	fmt.Printf("%q\n", tnClassifyDeletedDataset("2026-03-09T12:00:00Z", false, "", now))
+	fmt.Printf("%q\n", tnClassifyDeletedDataset("-", true, "", now))
+	fmt.Printf("%q\n", tnClassifyDeletedDataset("-", false, "7d", now))
+
+	// Output: "retained"
+	// "expired"
+	// "retained"
+	// "orphaned"
+	// ""
+	// "orphaned"
+}
+
+func Example_truenas_staleTemporaryDataset() {
+	fmt.Println(tnStaleTemporaryDataset("/custom/default_data_snap0_node1-1234.incustmp", "node1", 1234))
+	fmt.Println(tnStaleTemporaryDataset("/custom/default_data_snap0_node1-1000.incustmp", "node1", 1234))
+	fmt.Println(tnStaleTemporaryDataset("/custom/default_data_snap0_node2-1000.incustmp", "node1", 1234))
+	fmt.Println(tnStaleTemporaryDataset("/custom/default_data", "node1", 1234))
+
+	// Output: false
+	// true
+	// false
+	// false
+}
+
+func Example_truenas_filesystemCandidates() {
+	fmt.Println(tnFilesystemCandidates("ext4"))
+	fmt.Println(tnFilesystemCandidates("xfs"))
+
+	// Output: [ext4 btrfs xfs]
+	// [xfs btrfs ext4]
+}
+
+func Example_truenas_clockSkew() {
+	out := strings.Join([]string{
+		"pool/incus/custom/default_a\t1773144000\t2026-03-10T12:00:00Z",
+		"pool/incus/custom/default_b\t1773147600\t2026-03-10T13:10:00Z", // replicated, metadata set 10 minutes later
+		"pool/incus/custom/default_c\t1773147630\t2026-03-10T13:00:00Z",
+		"pool/incus/custom/default_d\t1773140000\t-",
+	}, "\n")
+
+	fmt.Println(tnClockSkew(out))
+	fmt.Println(tnClockSkew("pool/incus/custom/default_a\t1773144000\t2026-03-10T11:00:00Z"))
+	fmt.Println(tnClockSkew("pool/incus/custom/default_d\t1773140000\t-"))
+
+	// Output: 0s true
+	// 1h0m0s true
+	// 0s false
+}
+
+func Example_truenas_validateCompression() {
+	for _, value := range []string{"lz4", "gzip-9", "zstd-19", "zstd-fast-1000", "zstd-fast-30", "gzip-10", "zstd-fast-15", "zstd-fast-0", "brotli"} {
+		fmt.Printf("%s: %v\n", value, tnValidateCompression(value))
+	}
+
+	// Output: lz4: <nil>
+	// gzip-9: <nil>
+	// zstd-19: <nil>
+	// zstd-fast-1000: <nil>
+	// zstd-fast-30: <nil>
+	// gzip-10: Invalid compression "gzip-10", must be one of on, off, lz4, lzjb, zle, gzip[-1-9], zstd[-1-19] or zstd-fast[-level]
+	// zstd-fast-15: Invalid compression "zstd-fast-15", must be one of on, off, lz4, lzjb, zle, gzip[-1-9], zstd[-1-19] or zstd-fast[-level]
+	// zstd-fast-0: Invalid compression "zstd-fast-0", must be one of on, off, lz4, lzjb, zle, gzip[-1-9], zstd[-1-19] or zstd-fast[-level]
+	// brotli: Invalid compression "brotli", must be one of on, off, lz4, lzjb, zle, gzip[-1-9], zstd[-1-19] or zstd-fast[-level]
+}
+
+func Example_truenas_compressionInfo() {
+	fmt.Println(tnCompressionInfo("zstd", "1.52"))
+	fmt.Println(tnCompressionInfo("lz4", "2.10x"))
+	fmt.Println(tnCompressionInfo("-", "-"))
+
+	// Output: map[compression:zstd compression_ratio:1.52x]
+	// map[compression:lz4 compression_ratio:2.10x]
+	// map[]
+}
@@ -2,13 +2,19 @@ package drivers
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"io/fs"
+	"maps"
 	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"golang.org/x/sys/unix"
@@ -17,11 +23,13 @@ import (
 	"github.com/lxc/incus/v6/internal/linux"
 	"github.com/lxc/incus/v6/internal/migration"
 	"github.com/lxc/incus/v6/internal/server/backup"
+	"github.com/lxc/incus/v6/internal/server/locking"
 	localMigration "github.com/lxc/incus/v6/internal/server/migration"
 	"github.com/lxc/incus/v6/internal/server/operations"
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/logger"
 	"github.com/lxc/incus/v6/shared/revert"
+	"github.com/lxc/incus/v6/shared/subprocess"
 	"github.com/lxc/incus/v6/shared/units"
 	"github.com/lxc/incus/v6/shared/util"
 	"github.com/lxc/incus/v6/shared/validate"
@@ -30,10 +38,38 @@ import (
 // CreateVolume creates an empty volume and can optionally fill it by executing the supplied
 // filler function.
 func (d *truenas) CreateVolume(vol Volume, filler *VolumeFiller, op *operations.Operation) error {
+	defer trackOperation("truenas", d.name, "CreateVolume")()
+
+	err := d.checkWritable()
+	if err != nil {
+		return err
+	}
+
 	// Revert handling
 	reverter := revert.New()
 	defer reverter.Fail()
 
+	if vol.volType == VolumeTypeImage {
+		// Only allow one unpack of a given image at a time.
+		unlock, err := locking.Lock(context.TODO(), OperationLockName("CreateImageVolume", d.name, vol.volType, vol.contentType, vol.name))
+		if err != nil {
+			return err
+		}
+
+		defer unlock()
+
+		// The pool is shared, so the image may have been created in the meantime (e.g. by another cluster member).
+		exists, err := d.datasetExists(fmt.Sprintf("%s@readonly", d.dataset(vol, false)))
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			d.logger.Debug("Reusing existing cached image volume", logger.Ctx{"fingerprint": vol.Name()})
+			return nil
+		}
+	}
+
 	if vol.contentType == ContentTypeFS {
 		// Create mountpoint.
 		err := vol.EnsureMountPath(true)
@@ -83,10 +119,10 @@ func (d *truenas) CreateVolume(vol Volume, filler *VolumeFiller, op *operations.
 				return err
 			}
 
-			// If the cached volume size is different than the pool volume size, then we can't use the
-			// deleted cached image volume and instead we will rename it to a random UUID so it can't
-			// be restored in the future and a new cached image volume will be created instead.
-			if volSizeBytes != poolVolSizeBytes {
+			// If the cached volume size doesn't suit the pool volume size (see truenas.image_restore),
+			// then we can't use the deleted cached image volume and instead we will rename it to a random
+			// UUID so it can't be restored in the future and a new cached image volume will be created instead.
+			if !tnCanRestoreImage(d.config["truenas.image_restore"], volSizeBytes, poolVolSizeBytes) {
 				d.logger.Debug("Renaming deleted cached image volume so that regeneration is used", logger.Ctx{"fingerprint": vol.Name()})
 				randomVol := NewVolume(d, d.name, vol.volType, vol.contentType, d.randomVolumeName(vol), vol.config, vol.poolConfig)
 
@@ -121,6 +157,19 @@ func (d *truenas) CreateVolume(vol Volume, filler *VolumeFiller, op *operations.
 				// After this point we have a restored image, so setup reverter.
 				reverter.Add(func() { _ = d.DeleteVolume(vol, op) })
 
+				// Grow the restored image to the current size policy so it isn't regenerated the next
+				// time it's used. Instances are cloned from its read-only snapshot, which keeps the
+				// cached data and size, so this doesn't touch the image's content. Larger images
+				// restored with truenas.image_restore=always are left as they are, as shrinking
+				// would cut into the image's data.
+				if volSizeBytes < poolVolSizeBytes {
+					d.logger.Debug("Resizing restored cached image volume", logger.Ctx{"fingerprint": vol.Name(), "size": poolVolSizeBytes})
+					err = d.setVolsize(d.dataset(vol, false), poolVolSizeBytes, false)
+					if err != nil {
+						return err
+					}
+				}
+
 				if vol.IsVMBlock() {
 					fsVol := vol.NewVMBlockFilesystemVolume()
 					_, err := d.runTool("dataset", "rename", d.dataset(fsVol, true), d.dataset(fsVol, false))
@@ -144,6 +193,11 @@ func (d *truenas) CreateVolume(vol Volume, filler *VolumeFiller, op *operations.
 		opts = append(opts, fmt.Sprintf("user-props=incus:content_type=%s", vol.contentType))
 	}
 
+	// Record which Incus object the dataset is created for.
+	opts = append(opts, d.creationMetadataOptions(vol)...)
+	opts = append(opts, d.userPropertyOptions(vol)...)
+	opts = append(opts, d.volumePropertyOptions(vol)...)
+
 	blockSize := vol.ExpandedConfig("truenas.blocksize")
 	if blockSize != "" {
 		// Convert to bytes.
@@ -170,6 +224,11 @@ func (d *truenas) CreateVolume(vol Volume, filler *VolumeFiller, op *operations.
 		return err
 	}
 
+	err = d.ensureDatasetParent(vol)
+	if err != nil {
+		return err
+	}
+
 	dataset := d.dataset(vol, false)
 
 	// Create the volume dataset.
@@ -304,7 +363,11 @@ func (d *truenas) CreateVolume(vol Volume, filler *VolumeFiller, op *operations.
 			if err != nil {
 				return err
 			}
+
+			d.unshareImageDataset(d.dataset(fsVol, false))
 		}
+
+		d.unshareImageDataset(dataset)
 	}
 
 	// All done.
@@ -315,6 +378,11 @@ func (d *truenas) CreateVolume(vol Volume, filler *VolumeFiller, op *operations.
 
 // CreateVolumeFromBackup re-creates a volume from its exported state.
 func (d *truenas) CreateVolumeFromBackup(vol Volume, srcBackup backup.Info, srcData io.ReadSeeker, op *operations.Operation) (VolumePostHook, revert.Hook, error) {
+	err := d.checkWritable()
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// TODO: optimized version
 
 	return genericVFSBackupUnpack(d, d.state.OS, vol, srcBackup.Snapshots, srcData, op)
@@ -322,7 +390,10 @@ func (d *truenas) CreateVolumeFromBackup(vol Volume, srcBackup backup.Info, srcD
 
 // same as CreateVolumeFromCopy, but will refresh if refresh is true.
 func (d *truenas) createOrRefeshVolumeFromCopy(vol Volume, srcVol Volume, refresh bool, copySnapshots bool, allowInconsistent bool, op *operations.Operation) error {
-	var err error
+	err := d.checkWritable()
+	if err != nil {
+		return err
+	}
 
 	// Revert handling
 	reverter := revert.New()
@@ -338,6 +409,15 @@ func (d *truenas) createOrRefeshVolumeFromCopy(vol Volume, srcVol Volume, refres
 		reverter.Add(func() { _ = os.Remove(vol.MountPath()) })
 	}
 
+	// The source may live in another pool on the same TrueNAS host (see CanCopyVolumesFrom).
+	srcDriver := d
+	otherDriver, ok := srcVol.driver.(*truenas)
+	if ok {
+		srcDriver = otherDriver
+	}
+
+	crossPool := srcDriver.name != d.name
+
 	// For VMs, also copy the filesystem dataset.
 	if vol.IsVMBlock() {
 		// For VMs, also copy the filesystem volume.
@@ -358,7 +438,7 @@ func (d *truenas) createOrRefeshVolumeFromCopy(vol Volume, srcVol Volume, refres
 	// Retrieve snapshots on the source.
 	snapshots := []string{}
 	if !srcVol.IsSnapshot() && copySnapshots {
-		snapshots, err = d.VolumeSnapshots(srcVol, op)
+		snapshots, err = srcDriver.VolumeSnapshots(srcVol, op)
 		if err != nil {
 			return err
 		}
@@ -378,7 +458,11 @@ func (d *truenas) createOrRefeshVolumeFromCopy(vol Volume, srcVol Volume, refres
 		reverter.Add(func() { _ = unfreezeFS() })
 	}
 
-	srcDataset := d.dataset(srcVol, false)
+	srcDataset := srcDriver.dataset(srcVol, false)
+
+	// Clones can't span pools, so copies from another pool always use replication.
+	// Independent volumes must not depend on their source, so are always fully copied too.
+	fullCopy := util.IsFalse(d.config["truenas.clone_copy"]) || len(snapshots) > 0 || crossPool || util.IsTrue(vol.config["truenas.independent"])
 
 	var srcSnapshot string
 	if srcVol.volType == VolumeTypeImage {
@@ -394,8 +478,8 @@ func (d *truenas) createOrRefeshVolumeFromCopy(vol Volume, srcVol Volume, refres
 			return err
 		}
 
-		// If truenas.clone_copy is disabled delete the snapshot at the end.
-		if util.IsFalse(d.config["truenas.clone_copy"]) || len(snapshots) > 0 {
+		// If a full copy is made delete the snapshot at the end.
+		if fullCopy {
 			// Delete the snapshot at the end.
 			defer func() {
 				// Delete snapshot (or mark for deferred deletion if cannot be deleted currently).
@@ -426,10 +510,15 @@ func (d *truenas) createOrRefeshVolumeFromCopy(vol Volume, srcVol Volume, refres
 		reverter.Add(func() { _ = d.DeleteVolume(vol, op) })
 	}
 
+	err = d.ensureDatasetParent(vol)
+	if err != nil {
+		return err
+	}
+
 	destDataset := d.dataset(vol, false)
 
-	// If truenas.clone_copy is disabled or source volume has snapshots, then use full copy mode.
-	if util.IsFalse(d.config["truenas.clone_copy"]) || len(snapshots) > 0 {
+	// If truenas.clone_copy is disabled, source volume has snapshots or is in another pool, then use full copy mode.
+	if fullCopy {
 		// Run the replication, snaps + copy- snap. TODO: verify necessary props are replicated.
 		args := []string{"replication", "start", "--recursive", "--readonly-policy=ignore"}
 
@@ -449,11 +538,14 @@ func (d *truenas) createOrRefeshVolumeFromCopy(vol Volume, srcVol Volume, refres
 			ability to filter snapshots as they are sent.
 		*/
 		snapName := strings.SplitN(srcSnapshot, "@", 2)[1]
-		snapRegex := fmt.Sprintf("(snapshot-.*|%s)", snapName)
+		snapRegex := fmt.Sprintf("(%s.*|%s)", regexp.QuoteMeta(d.snapshotPrefix()), snapName)
 
 		args = append(args, "--name-regex", snapRegex, srcDataset, destDataset)
 
+		// The replication runs on the remote host, so report progress from the growth of the destination.
+		stopProgress := d.trackDatasetProgress(destDataset, vol.name, op)
 		_, err := d.runTool(args...)
+		stopProgress()
 		if err != nil {
 			return fmt.Errorf("Failed to replicate dataset: %w", err)
 		}
@@ -481,6 +573,15 @@ func (d *truenas) createOrRefeshVolumeFromCopy(vol Volume, srcVol Volume, refres
 		}
 	}
 
+	// Record which Incus object the copy belongs to rather than keeping the source's metadata.
+	// Clones don't inherit their origin's sync mode or compression, so they are applied too.
+	props := append(d.creationMetadataOptions(vol), d.userPropertyOptions(vol)...)
+	props = append(props, d.volumePropertyOptions(vol)...)
+	err = d.setDatasetProperties(destDataset, props...)
+	if err != nil {
+		return err
+	}
+
 	// and share the clone/copy.
 	err = d.createIscsiShare(destDataset, false)
 	if err != nil {
@@ -534,13 +635,39 @@ func (d *truenas) createOrRefeshVolumeFromCopy(vol Volume, srcVol Volume, refres
 	return nil
 }
 
-// CreateVolumeFromCopy provides same-pool volume copying functionality.
+// CanCopyVolumesFrom returns whether volumes can be copied from another TrueNAS pool on the same host
+// using a replication task on the host itself rather than streaming them through this server.
+func (d *truenas) CanCopyVolumesFrom(srcDriver Driver) bool {
+	src, ok := srcDriver.(*truenas)
+	if !ok {
+		return false
+	}
+
+	// Both pools must reach the same host with the same credentials and name their snapshots alike.
+	for _, key := range []string{"truenas.allow_insecure", "truenas.api_key", "truenas.config", "truenas.host"} {
+		if d.config[key] != src.config[key] {
+			return false
+		}
+	}
+
+	return d.snapshotPrefix() == src.snapshotPrefix()
+}
+
+// CreateVolumeFromCopy provides same-pool volume copying functionality, as well as copying from another
+// pool on the same TrueNAS host.
 func (d *truenas) CreateVolumeFromCopy(vol Volume, srcVol Volume, copySnapshots bool, allowInconsistent bool, op *operations.Operation) error {
+	defer trackOperation("truenas", d.name, "CreateVolumeFromCopy")()
+
 	return d.createOrRefeshVolumeFromCopy(vol, srcVol, false, copySnapshots, allowInconsistent, op) // not refreshing.
 }
 
 // CreateVolumeFromMigration creates a volume being sent via a migration. TODO: need to ensure that incus:content_type is copied.
 func (d *truenas) CreateVolumeFromMigration(vol Volume, conn io.ReadWriteCloser, volTargetArgs localMigration.VolumeTargetArgs, preFiller *VolumeFiller, op *operations.Operation) error {
+	err := d.checkWritable()
+	if err != nil {
+		return err
+	}
+
 	if volTargetArgs.ClusterMoveSourceName != "" && volTargetArgs.StoragePool == "" {
 		d.logger.Debug("Detected migration between cluster members on the same storage pool")
 		err := vol.EnsureMountPath(false)
@@ -559,6 +686,12 @@ func (d *truenas) CreateVolumeFromMigration(vol Volume, conn io.ReadWriteCloser,
 		return nil
 	}
 
+	// Apply the pool transfer limit (if any) to the incoming stream.
+	conn, err = d.throttleConn(conn)
+	if err != nil {
+		return err
+	}
+
 	// Handle simple rsync and block_and_rsync through generic.
 	if volTargetArgs.MigrationType.FSType == migration.MigrationFSType_RSYNC || volTargetArgs.MigrationType.FSType == migration.MigrationFSType_BLOCK_AND_RSYNC {
 		return genericVFSCreateVolumeFromMigration(d, nil, vol, conn, volTargetArgs, preFiller, op)
@@ -571,6 +704,8 @@ func (d *truenas) CreateVolumeFromMigration(vol Volume, conn io.ReadWriteCloser,
 
 // RefreshVolume updates an existing volume to match the state of another.
 func (d *truenas) RefreshVolume(vol Volume, srcVol Volume, srcSnapshots []Volume, allowInconsistent bool, op *operations.Operation) error {
+	defer trackOperation("truenas", d.name, "RefreshVolume")()
+
 	var err error
 	var targetSnapshots []Volume
 	var srcSnapshotsAll []Volume
@@ -607,6 +742,13 @@ func (d *truenas) RefreshVolume(vol Volume, srcVol Volume, srcSnapshots []Volume
 // this function will return an error.
 // For image volumes, both filesystem and block volumes will be removed.
 func (d *truenas) DeleteVolume(vol Volume, op *operations.Operation) error {
+	defer trackOperation("truenas", d.name, "DeleteVolume")()
+
+	err := d.checkWritable()
+	if err != nil {
+		return err
+	}
+
 	if vol.volType == VolumeTypeImage && vol.contentType == ContentTypeFS {
 		// deletes all block.filesystem permutations
 		return d.deleteImageFsVolume(vol, op)
@@ -708,7 +850,13 @@ func (d *truenas) deleteVolume(vol Volume, optionalExistance *bool, op *operatio
 			return err
 		}
 
-		if len(clones) > 0 {
+		if d.config["truenas.delete_retention"] != "" && vol.volType != VolumeTypeImage {
+			// Keep the volume around for the retention period.
+			err := d.retainDeletedDataset(dataset, d.retainedDatasetName(vol))
+			if err != nil {
+				return err
+			}
+		} else if len(clones) > 0 {
 			// Move to the deleted path.
 			err := d.renameDataset(dataset, d.dataset(vol, true), false)
 			if err != nil {
@@ -781,8 +929,12 @@ func (d *truenas) commonVolumeRules() map[string]func(value string) error {
 		"block.filesystem":         validate.Optional(validate.IsOneOf(blockBackedAllowedFilesystems...)),
 		"block.mount_options":      validate.IsAny,
 		"truenas.blocksize":        validate.Optional(ValidateTrueNasVolBlocksize), // used for volblocksize only. NOTE: zfs.blocksize is hard-coded in backend.shouldUseOptimizedImage...
+		"truenas.compression":      validate.Optional(tnValidateCompression),
 		"truenas.remove_snapshots": validate.Optional(validate.IsBool),
+		"truenas.restore_mode":     validate.Optional(validate.IsOneOf("rollback", "copy")),
+		"truenas.sync":             validate.Optional(validate.IsOneOf(tnSyncModes...)),
 		"truenas.use_refquota":     validate.Optional(validate.IsBool),
+		"truenas.user_properties":  validate.Optional(tnValidateUserProperties),
 	}
 }
 
@@ -799,11 +951,64 @@ func (d *truenas) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
 		delete(commonRules, "block.mount_options")
 	}
 
+	// Only custom volumes can be detached from their origin or relocated under a different parent dataset.
+	if vol.volType == VolumeTypeCustom {
+		commonRules["truenas.independent"] = validate.Optional(validate.IsBool)
+		commonRules["truenas.parent"] = validate.Optional(tnValidateDatasetParent)
+	}
+
 	return d.validateVolume(vol, commonRules, removeUnknownKeys)
 }
 
 // UpdateVolume applies config changes to the volume.
 func (d *truenas) UpdateVolume(vol Volume, changedConfig map[string]string) error {
+	err := d.checkWritable()
+	if err != nil {
+		return err
+	}
+
+	// The filesystem lives on the block volume so its settings are fixed once created.
+	for _, key := range []string{"block.filesystem", "block.mount_options"} {
+		_, changed := changedConfig[key]
+		if changed {
+			return fmt.Errorf("%s cannot be changed after creation", key)
+		}
+	}
+
+	value, ok := changedConfig["truenas.user_properties"]
+	if ok {
+		err := d.updateUserProperties(vol, vol.ExpandedConfig("truenas.user_properties"), value)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(tnVolumeProperties)) {
+		value, ok := changedConfig[key]
+		if ok {
+			err := d.updateVolumeProperty(vol, key, value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value, ok = changedConfig["truenas.independent"]
+	if ok && util.IsTrue(value) {
+		err := d.detachVolume(vol)
+		if err != nil {
+			return err
+		}
+	}
+
+	value, ok = changedConfig["truenas.parent"]
+	if ok {
+		err := d.relocateVolume(vol, value)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Mangle the current volume to its old values.
 	old := make(map[string]string)
 	for k, v := range changedConfig {
@@ -830,6 +1035,167 @@ func (d *truenas) UpdateVolume(vol Volume, changedConfig map[string]string) erro
 	return nil
 }
 
+// detachVolume replaces a volume cloned from a snapshot with a full copy of it, so that it no longer depends
+// on its origin. The clone isn't promoted as that would move the origin's earlier snapshots to it.
+func (d *truenas) detachVolume(vol Volume) error {
+	dataset := d.dataset(vol, false)
+
+	origin, err := d.getDatasetProperty(dataset, "origin")
+	if err != nil {
+		return err
+	}
+
+	// Nothing to do if the volume isn't a clone.
+	if origin == "" || origin == "-" {
+		return nil
+	}
+
+	devPath, err := d.locateIscsiDataset(dataset)
+	if err != nil {
+		return err
+	}
+
+	if devPath != "" {
+		return errors.New("Cannot detach a volume that is in use")
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	// Take a snapshot of the current state to replicate along with the volume snapshots.
+	snapName := fmt.Sprintf("detach-%s", uuid.New().String())
+	err = d.createSnapshot(fmt.Sprintf("%s@%s", dataset, snapName), false)
+	if err != nil {
+		return err
+	}
+
+	reverter.Add(func() { _ = d.deleteSnapshot(fmt.Sprintf("%s@%s", dataset, snapName), true, "defer") })
+
+	// Replicate into a temporary dataset in the deleted area.
+	tmpDataset := filepath.Join(d.config["truenas.dataset"], "deleted", string(vol.volType), uuid.New().String())
+	snapRegex := fmt.Sprintf("(%s.*|%s)", regexp.QuoteMeta(d.snapshotPrefix()), snapName)
+	_, err = d.runTool("replication", "start", "--recursive", "--readonly-policy=ignore", "--name-regex", snapRegex, dataset, tmpDataset)
+	if err != nil {
+		return fmt.Errorf("Failed to replicate dataset: %w", err)
+	}
+
+	reverter.Add(func() { _ = d.deleteDatasetRecursive(tmpDataset) })
+
+	err = d.deleteSnapshot(fmt.Sprintf("%s@%s", tmpDataset, snapName), true)
+	if err != nil {
+		return err
+	}
+
+	// Swap the clone for the copy.
+	oldDataset := filepath.Join(d.config["truenas.dataset"], "deleted", string(vol.volType), uuid.New().String())
+	err = d.renameDataset(dataset, oldDataset, true)
+	if err != nil {
+		return err
+	}
+
+	reverter.Add(func() {
+		_ = d.renameDataset(oldDataset, dataset, true)
+		_ = d.createIscsiShare(dataset, false)
+	})
+
+	err = d.renameDataset(tmpDataset, dataset, false)
+	if err != nil {
+		return err
+	}
+
+	reverter.Add(func() { _ = d.renameDataset(dataset, tmpDataset, false) })
+
+	// User properties aren't necessarily replicated.
+	props := []string{fmt.Sprintf("user-props=incus:content_type=%s", vol.contentType)}
+	props = append(props, d.userPropertyOptions(vol)...)
+	props = append(props, d.volumePropertyOptions(vol)...)
+	err = d.setDatasetProperties(dataset, props...)
+	if err != nil {
+		return err
+	}
+
+	err = d.createIscsiShare(dataset, false)
+	if err != nil {
+		return err
+	}
+
+	reverter.Success()
+
+	// Drop the clone, which also removes its origin if that was only kept around for it.
+	err = d.deleteDatasetRecursive(oldDataset)
+	if err != nil {
+		d.logger.Warn("Failed deleting detached clone", logger.Ctx{"dataset": oldDataset, "err": err})
+	}
+
+	d.logger.Info("Detached TrueNAS volume from its origin", logger.Ctx{"volName": vol.name, "origin": origin})
+
+	return nil
+}
+
+// relocateVolume moves the dataset of a custom volume under a different parent dataset of the pool.
+// The mount path only depends on the volume name so is unaffected, but the iSCSI share follows the dataset.
+func (d *truenas) relocateVolume(vol Volume, parent string) error {
+	newVol := vol.Clone()
+	newVol.config["truenas.parent"] = parent
+
+	srcDataset := d.dataset(vol, false)
+	dstDataset := d.dataset(newVol, false)
+	if srcDataset == dstDataset {
+		return nil
+	}
+
+	// Snapshots keep the parent recorded when they were taken, so would point at the old location.
+	snapshots, err := d.VolumeSnapshots(vol, nil)
+	if err != nil {
+		return err
+	}
+
+	if len(snapshots) > 0 {
+		return errors.New("Cannot relocate a volume that has snapshots")
+	}
+
+	devPath, err := d.locateIscsiDataset(srcDataset)
+	if err != nil {
+		return err
+	}
+
+	if devPath != "" {
+		return errors.New("Cannot relocate a volume that is in use")
+	}
+
+	exists, err := d.datasetExists(dstDataset)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return fmt.Errorf("Dataset %q already exists", dstDataset)
+	}
+
+	err = d.ensureDatasetParent(newVol)
+	if err != nil {
+		return err
+	}
+
+	err = d.renameDataset(srcDataset, dstDataset, true)
+	if err != nil {
+		return fmt.Errorf("Failed relocating volume to %q: %w", dstDataset, err)
+	}
+
+	// Record the new parent so that the volume can still be found when listing the pool's volumes. It's only
+	// looked at for datasets outside of the volume type datasets, so doesn't need clearing when moving back.
+	if parent != "" {
+		err = d.setDatasetProperties(dstDataset, fmt.Sprintf("user-props=%s=%s", tnPropParent, parent))
+		if err != nil {
+			return err
+		}
+	}
+
+	d.logger.Info("Relocated TrueNAS volume", logger.Ctx{"volName": vol.name, "from": srcDataset, "to": dstDataset})
+
+	return nil
+}
+
 // CacheVolumeSnapshots fetches snapshot usage properties for all snapshots on the volume.
 func (d *truenas) CacheVolumeSnapshots(vol Volume) error {
 	// NOTE: this actually gets info for all datasets and snapshots.
@@ -939,6 +1305,31 @@ func (d *truenas) GetVolumeUsage(vol Volume) (int64, error) {
 	return valueInt, nil
 }
 
+// GetVolumeInfo returns whether the volume is a ZFS clone and, if so, the snapshot it was cloned from, along with
+// its compression algorithm and the compression ratio achieved.
+func (d *truenas) GetVolumeInfo(vol Volume) (map[string]string, error) {
+	if vol.IsSnapshot() {
+		return nil, ErrNotSupported
+	}
+
+	dataset := d.dataset(vol, false)
+
+	origin, err := d.getDatasetProperty(dataset, "origin")
+	if err != nil {
+		return nil, err
+	}
+
+	props, err := d.getDatasetProperties(dataset, []string{"compression", "compressratio"})
+	if err != nil {
+		return nil, err
+	}
+
+	info := tnCloneInfo(origin)
+	maps.Copy(info, tnCompressionInfo(props["compression"], props["compressratio"]))
+
+	return info, nil
+}
+
 // SetVolumeQuota sets the quota/reservation on the volume.
 // Does nothing if supplied with an empty/zero size for block volumes.
 func (d *truenas) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool, op *operations.Operation) error {
@@ -979,6 +1370,11 @@ func (d *truenas) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool
 		return nil
 	}
 
+	err = d.checkWritable()
+	if err != nil {
+		return err
+	}
+
 	if vol.contentType == ContentTypeFS {
 		// Activate volume if needed.
 		activated, volDevPath, err := d.activateVolume(vol)
@@ -1029,6 +1425,12 @@ func (d *truenas) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool
 				return err
 			}
 
+			// The iSCSI device only reports the new size once rescanned.
+			err = d.rescanBlockDevice(volDevPath, sizeBytes)
+			if err != nil {
+				return err
+			}
+
 			// Grow the filesystem to fill block device.
 			err = growFileSystem(fsType, volDevPath, vol)
 			if err != nil {
@@ -1058,6 +1460,21 @@ func (d *truenas) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool
 		if err != nil {
 			return err
 		}
+
+		// Let an active iSCSI device, such as the disk of a running VM, pick up the new size.
+		if sizeBytes > oldVolSizeBytes {
+			volDevPath, err := d.locateIscsiDataset(dataset)
+			if err != nil {
+				return err
+			}
+
+			if volDevPath != "" {
+				err = d.rescanBlockDevice(volDevPath, sizeBytes)
+				if err != nil {
+					return err
+				}
+			}
+		}
 	}
 
 	// Move the VM GPT alt header to end of disk if needed (not needed in unsafe resize mode as
@@ -1122,7 +1539,7 @@ func (d *truenas) ListVolumes() ([]Volume, error) {
 	// However for custom block volumes it does not also end the volume name in zfsBlockVolSuffix (unlike the
 	// LVM and Ceph drivers), so we must also retrieve the dataset type here and look for "volume" types
 	// which also indicate this is a block volume.
-	out, err := d.runTool("list", "--no-headers", "-o", "name,incus:content_type", "-r", "-t", "volume", d.config["truenas.dataset"])
+	out, err := d.runTool("list", "--no-headers", "-o", "name,incus:content_type,"+tnPropBlockFilesystem+","+tnPropParent, "-r", "-t", "volume", d.config["truenas.dataset"])
 	if err != nil {
 		return nil, err
 	}
@@ -1133,16 +1550,19 @@ func (d *truenas) ListVolumes() ([]Volume, error) {
 		line := strings.TrimSpace(scanner.Text())
 
 		parts := strings.Split(line, "\t")
-		if len(parts) != 2 {
+		if len(parts) != 4 {
 			return nil, fmt.Errorf("Unexpected volume line %q", line)
 		}
 
 		zfsVolName := parts[0]
 		incusContentType := parts[1]
+		blockFilesystem := parts[2]
+		parent := parts[3]
 
 		var volType VolumeType
 		var volName string
 		var volFs string
+		var volParent string
 
 		for _, volumeType := range d.Info().VolumeTypes {
 			prefix := fmt.Sprintf("%s/%s/", d.config["truenas.dataset"], volumeType)
@@ -1152,28 +1572,32 @@ func (d *truenas) ListVolumes() ([]Volume, error) {
 			}
 		}
 
+		// Custom volumes relocated through truenas.parent live outside of the volume type datasets.
+		if volType == "" && parent != "-" && parent != "" {
+			prefix := fmt.Sprintf("%s/%s/", d.config["truenas.dataset"], parent)
+			name := strings.TrimPrefix(zfsVolName, prefix)
+			if strings.HasPrefix(zfsVolName, prefix) && !strings.Contains(name, "/") {
+				volType = VolumeTypeCustom
+				volName = name
+				volParent = parent
+			}
+		}
+
 		if volType == "" {
 			d.logger.Debug("Ignoring unrecognised volume type", logger.Ctx{"name": zfsVolName})
 			continue // Ignore unrecognised volume.
 		}
 
-		contentType := ContentTypeFS
-
 		if volType == VolumeTypeVM && !strings.HasSuffix(volName, zfsBlockVolSuffix) {
 			continue // Ignore VM filesystem volumes as we will just return the VM's block volume.
 		}
 
-		if volType == VolumeTypeCustom && strings.HasSuffix(volName, zfsISOVolSuffix) {
-			contentType = ContentTypeISO
-			volName = strings.TrimSuffix(volName, zfsISOVolSuffix)
-		} else if volType == VolumeTypeVM || (volType == VolumeTypeImage && strings.HasSuffix(volName, zfsBlockVolSuffix)) {
-			contentType = ContentTypeBlock
-			volName = strings.TrimSuffix(volName, zfsBlockVolSuffix)
-		}
+		var contentType ContentType
+		contentType, volName, volFs = tnVolumeContentType(volType, volName, incusContentType)
 
-		// FS images have the FS encoded after a _ separator
-		if volType == VolumeTypeImage && strings.Contains(volName, "_") {
-			volName, volFs, _ = strings.Cut(volName, "_")
+		// Use the filesystem recorded when the volume was created (or first probed), if any.
+		if volFs == "" && contentType == ContentTypeFS && blockFilesystem != "-" && blockFilesystem != "" {
+			volFs = blockFilesystem
 		}
 
 		// If a new volume has been found, or the volume will replace an existing image filesystem volume
@@ -1188,9 +1612,8 @@ func (d *truenas) ListVolumes() ([]Volume, error) {
 				v.config["block.filesystem"] = volFs
 			}
 
-			// Get correct content type from incus:content_type property.
-			if incusContentType != "-" {
-				v.contentType = ContentType(incusContentType)
+			if volParent != "" {
+				v.config["truenas.parent"] = volParent
 			}
 
 			/*
@@ -1216,6 +1639,62 @@ func (d *truenas) ListVolumes() ([]Volume, error) {
 	return volList, nil
 }
 
+// ReconcileVolumes repairs the incus:content_type property of the datasets of the given custom volumes when it
+// doesn't match the content type Incus has recorded for them, returning the changes made.
+func (d *truenas) ReconcileVolumes(vols []Volume) ([]string, error) {
+	// Read-only pools leave the dataset properties as they are.
+	if d.isReadonly() {
+		return nil, ErrNotSupported
+	}
+
+	out, err := d.runTool("list", "--no-headers", "-o", "name,incus:content_type", "-r", "-t", "volume", d.config["truenas.dataset"])
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := tnParseDatasetProperty(out)
+	if err != nil {
+		return nil, err
+	}
+
+	expected := make(map[string]ContentType, len(vols))
+	for _, vol := range vols {
+		if vol.volType != VolumeTypeCustom || vol.IsSnapshot() {
+			continue
+		}
+
+		dataset := d.dataset(vol, false)
+		_, found := current[dataset]
+		if !found {
+			// Volumes are all zvols, so a dataset of another type can't be fixed by a property change.
+			exists, err := d.datasetExists(dataset)
+			if err != nil {
+				return nil, err
+			}
+
+			if exists {
+				d.logger.Warn("Custom volume dataset isn't a ZFS volume", logger.Ctx{"volName": vol.name, "dataset": dataset})
+			}
+
+			continue
+		}
+
+		expected[dataset] = vol.contentType
+	}
+
+	changes := []string{}
+	for _, dataset := range tnContentTypeMismatches(current, expected) {
+		err = d.setDatasetProperties(dataset, fmt.Sprintf("user-props=incus:content_type=%s", expected[dataset]))
+		if err != nil {
+			return changes, fmt.Errorf("Failed fixing content type of %q: %w", dataset, err)
+		}
+
+		changes = append(changes, fmt.Sprintf("%s: incus:content_type changed from %q to %q", dataset, current[dataset], expected[dataset]))
+	}
+
+	return changes, nil
+}
+
 // activateVolume activates a ZFS volume if not already active. Returns true if activated, false if not.
 func (d *truenas) activateVolume(vol Volume) (bool, string, error) {
 	if !IsContentBlock(vol.contentType) && !vol.IsBlockBacked() {
@@ -1234,6 +1713,18 @@ func (d *truenas) activateVolume(vol Volume) (bool, string, error) {
 		d.logger.Debug("Activated TrueNAS volume", logger.Ctx{"volName": vol.Name(), "dev": dataset})
 	}
 
+	// Prevent any write to the volumes of read-only pools, including from instances using them directly.
+	if d.isReadonly() {
+		_, err = subprocess.RunCommand("blockdev", "--setro", devPath)
+		if err != nil {
+			if didActivate {
+				_ = d.deactivateIscsiDataset(dataset)
+			}
+
+			return false, "", fmt.Errorf("Failed making TrueNAS volume read-only: %w", err)
+		}
+	}
+
 	return didActivate, devPath, nil
 }
 
@@ -1260,6 +1751,8 @@ func (d *truenas) deactivateVolume(vol Volume) (bool, error) {
 
 // MountVolume mounts a volume and increments ref counter. Please call UnmountVolume() when done with the volume.
 func (d *truenas) MountVolume(vol Volume, op *operations.Operation) error {
+	defer trackOperation("truenas", d.name, "MountVolume")()
+
 	unlock, err := vol.MountLock()
 	if err != nil {
 		return err
@@ -1284,26 +1777,50 @@ func (d *truenas) MountVolume(vol Volume, op *operations.Operation) error {
 	case ContentTypeFS:
 		mountPath := vol.MountPath()
 		if !linux.IsMountPoint(mountPath) {
-			err := vol.EnsureMountPath(false)
+			err := d.ensureMountDir(vol)
 			if err != nil {
 				return err
 			}
 
-			fsType := vol.ConfigBlockFilesystem()
+			fsTypes := []string{vol.ConfigBlockFilesystem()}
 
 			if vol.mountFilesystemProbe {
-				fsType, err = fsProbe(volDevPath)
-				if err != nil {
-					return fmt.Errorf("Failed probing filesystem: %w", err)
+				fsType, err := fsProbe(volDevPath)
+				if err == nil && fsType != "" {
+					fsTypes = []string{fsType}
+				} else {
+					// Recovered volumes are tried with each supported filesystem rather than failing outright.
+					fsTypes = tnFilesystemCandidates(vol.ConfigBlockFilesystem())
+					d.logger.Warn("Failed probing filesystem, trying supported filesystems", logger.Ctx{"volName": vol.name, "dev": volDevPath, "filesystems": fsTypes, "err": err})
+				}
+			}
+
+			mountFlags, mountOptions := linux.ResolveMountOptions(strings.Split(d.blockMountOptions(vol), ","))
+
+			var fsType string
+			for _, fsType = range fsTypes {
+				err = TryMount(volDevPath, mountPath, fsType, mountFlags, mountOptions)
+				if err == nil {
+					break
+				}
+
+				if len(fsTypes) > 1 {
+					d.logger.Warn("Failed mounting volume", logger.Ctx{"volName": vol.name, "dev": volDevPath, "filesystem": fsType, "err": err})
 				}
 			}
 
-			mountFlags, mountOptions := linux.ResolveMountOptions(strings.Split(vol.ConfigBlockMountOptions(), ","))
-			err = TryMount(volDevPath, mountPath, fsType, mountFlags, mountOptions)
 			if err != nil {
 				return err
 			}
 
+			// Record the filesystem so that the volume doesn't need probing when recovered again.
+			if vol.mountFilesystemProbe && !vol.IsSnapshot() && !d.isReadonly() {
+				err = d.setDatasetProperties(d.dataset(vol, false), fmt.Sprintf("user-props=%s=%s", tnPropBlockFilesystem, fsType))
+				if err != nil {
+					d.logger.Warn("Failed recording the filesystem of the volume", logger.Ctx{"volName": vol.name, "err": err})
+				}
+			}
+
 			d.logger.Debug("Mounted TrueNAS volume", logger.Ctx{"volName": vol.name, "dev": volDevPath, "path": mountPath, "options": mountOptions})
 		}
 
@@ -1326,6 +1843,8 @@ func (d *truenas) MountVolume(vol Volume, op *operations.Operation) error {
 // UnmountVolume simulates unmounting a volume.
 // keepBlockDev indicates if backing block device should be not be unmapped if volume is unmounted.
 func (d *truenas) UnmountVolume(vol Volume, keepBlockDev bool, op *operations.Operation) (bool, error) {
+	defer trackOperation("truenas", d.name, "UnmountVolume")()
+
 	unlock, err := vol.MountLock()
 	if err != nil {
 		return false, err
@@ -1350,9 +1869,20 @@ func (d *truenas) UnmountVolume(vol Volume, keepBlockDev bool, op *operations.Op
 			return false, fmt.Errorf("Failed syncing filesystem %q: %w", mountPath, err)
 		}
 
-		err = TryUnmount(mountPath, unix.MNT_DETACH)
+		err = TryUnmount(mountPath, 0)
 		if err != nil {
-			return false, err
+			holders := tnMountHolders(mountPath)
+			if util.IsTrue(d.config["truenas.strict_unmount"]) {
+				d.logger.Warn("Failed unmounting TrueNAS volume", logger.Ctx{"volName": vol.name, "path": mountPath, "holders": holders, "err": err})
+				return false, err
+			}
+
+			d.logger.Warn("Lazily unmounting busy TrueNAS volume", logger.Ctx{"volName": vol.name, "path": mountPath, "holders": holders, "err": err})
+
+			err = TryUnmount(mountPath, unix.MNT_DETACH)
+			if err != nil {
+				return false, err
+			}
 		}
 
 		d.logger.Debug("Unmounted TrueNAS volume", logger.Ctx{"volName": vol.name, "path": mountPath, "keepBlockDev": keepBlockDev})
@@ -1395,6 +1925,11 @@ func (d *truenas) UnmountVolume(vol Volume, keepBlockDev bool, op *operations.Op
 
 // RenameVolume renames a volume and its snapshots.
 func (d *truenas) RenameVolume(vol Volume, newVolName string, op *operations.Operation) error {
+	err := d.checkWritable()
+	if err != nil {
+		return err
+	}
+
 	newVol := NewVolume(d, d.name, vol.volType, vol.contentType, newVolName, vol.config, vol.poolConfig)
 
 	// Revert handling.
@@ -1402,7 +1937,7 @@ func (d *truenas) RenameVolume(vol Volume, newVolName string, op *operations.Ope
 	defer reverter.Fail()
 
 	// First rename the VFS paths.
-	err := genericVFSRenameVolume(d, vol, newVolName, op)
+	err = genericVFSRenameVolume(d, vol, newVolName, op)
 	if err != nil {
 		return err
 	}
@@ -1421,6 +1956,20 @@ func (d *truenas) RenameVolume(vol Volume, newVolName string, op *operations.Ope
 		_ = d.renameDataset(d.dataset(newVol, false), d.dataset(vol, false), true)
 	})
 
+	// The dataset keeps its user properties across the rename, only refresh the ownership to match the new name.
+	metadata, err := d.getDatasetMetadata(d.dataset(newVol, false))
+	if err != nil {
+		return err
+	}
+
+	projectName, instanceName := d.datasetOwner(newVol)
+	if instanceName != "" && (metadata[tnPropProject] != projectName || metadata[tnPropInstance] != instanceName) {
+		err = d.setDatasetProperties(d.dataset(newVol, false), fmt.Sprintf("user-props=%s=%s", tnPropProject, projectName), fmt.Sprintf("user-props=%s=%s", tnPropInstance, instanceName))
+		if err != nil {
+			return err
+		}
+	}
+
 	// All done.
 	reverter.Success()
 
@@ -1433,6 +1982,12 @@ func (d *truenas) MigrateVolume(vol Volume, conn io.ReadWriteCloser, volSrcArgs
 		return nil // When performing a cluster member move don't do anything on the source member.
 	}
 
+	// Apply the pool transfer limit (if any) to the outgoing stream.
+	conn, err := d.throttleConn(conn)
+	if err != nil {
+		return err
+	}
+
 	// Handle simple rsync and block_and_rsync through generic.
 	if volSrcArgs.MigrationType.FSType == migration.MigrationFSType_RSYNC || volSrcArgs.MigrationType.FSType == migration.MigrationFSType_BLOCK_AND_RSYNC {
 		// TODO this should take a temporary snapshot.
@@ -1440,7 +1995,7 @@ func (d *truenas) MigrateVolume(vol Volume, conn io.ReadWriteCloser, volSrcArgs
 		// activated to avoid issues activating the snapshot volume device.
 		parent, _, _ := api.GetParentAndSnapshotName(vol.Name())
 		parentVol := NewVolume(d, d.Name(), vol.volType, vol.contentType, parent, vol.config, vol.poolConfig)
-		err := d.MountVolume(parentVol, op)
+		err = d.MountVolume(parentVol, op)
 		if err != nil {
 			return err
 		}
@@ -1457,17 +2012,54 @@ func (d *truenas) MigrateVolume(vol Volume, conn io.ReadWriteCloser, volSrcArgs
 
 // BackupVolume creates an exported version of a volume.
 func (d *truenas) BackupVolume(vol Volume, tarWriter *instancewriter.InstanceTarWriter, optimized bool, snapshots []string, op *operations.Operation) error {
+	// Throttle reading the volume's data from the TrueNAS host when the pool has a transfer limit.
+	limiter, err := d.transferLimiter()
+	if err != nil {
+		return err
+	}
+
+	if limiter != nil {
+		tarWriter.WrapReaders(func(r io.Reader) io.Reader { return &tnRateLimitedReader{Reader: r, limiter: limiter} })
+		defer tarWriter.WrapReaders(nil)
+	}
+
 	// TODO: we should take a snapshot, and backup from the snapshot for consistency.
 	return genericVFSBackupVolume(d, vol, tarWriter, snapshots, op)
 }
 
 // CreateVolumeSnapshot creates a snapshot of a volume.
 func (d *truenas) CreateVolumeSnapshot(vol Volume, op *operations.Operation) error {
-	parentName, _, _ := api.GetParentAndSnapshotName(vol.name)
+	defer trackOperation("truenas", d.name, "CreateVolumeSnapshot")()
 
-	// Revert handling.
-	reverter := revert.New()
-	defer reverter.Fail()
+	err := d.checkWritable()
+	if err != nil {
+		return err
+	}
+
+	vols := []Volume{vol}
+
+	// For VM images, create a filesystem volume too.
+	if vol.IsVMBlock() {
+		vols = append(vols, vol.NewVMBlockFilesystemVolume())
+	}
+
+	snapDatasets := make([]string, 0, len(vols))
+	for _, v := range vols {
+		err := d.prepareVolumeSnapshot(v)
+		if err != nil {
+			return err
+		}
+
+		snapDatasets = append(snapDatasets, d.dataset(v, false))
+	}
+
+	// Make the snapshots in a single call so they are taken atomically and the instance is unfrozen sooner.
+	return d.createSnapshots(snapDatasets, false)
+}
+
+// prepareVolumeSnapshot creates the snapshot mount path and flushes the volume to the server ahead of a snapshot.
+func (d *truenas) prepareVolumeSnapshot(vol Volume) error {
+	parentName, _, _ := api.GetParentAndSnapshotName(vol.name)
 
 	// Create the parent directory.
 	err := createParentSnapshotDirIfMissing(d.name, vol.volType, parentName)
@@ -1498,58 +2090,78 @@ func (d *truenas) CreateVolumeSnapshot(vol Volume, op *operations.Operation) err
 		*/
 		volMountPath := GetVolumeMountPath(vol.pool, vol.volType, parentName)
 		if linux.IsMountPoint(volMountPath) {
-			err := linux.SyncFS(volMountPath)
+			err := d.snapshotSync(volMountPath)
 			if err != nil {
 				return fmt.Errorf("Failed syncing filesystem %q: %w", volMountPath, err)
 			}
 		}
 	}
 
-	snapDataset := d.dataset(vol, false)
-
 	// Sync the device. It may not be enough to just sync the mountpoint... because the device may not be mounted.
-	parentDataset, _, ok := strings.Cut(snapDataset, "@")
+	parentDataset, _, ok := strings.Cut(d.dataset(vol, false), "@")
 	if ok {
 		devPath, err := d.locateIscsiDataset(parentDataset)
 		if err == nil && devPath != "" {
-			err := linux.SyncFS(devPath)
+			err := d.snapshotSync(devPath)
 			if err != nil {
 				return fmt.Errorf("Failed syncing device %q: %w", devPath, err)
 			}
 		}
 	}
 
-	// Make the snapshot.
-	err = d.createSnapshot(snapDataset, false)
+	return nil
+}
+
+// snapshotSync flushes a filesystem or device ahead of a snapshot. When truenas.snapshot_sync_timeout is set,
+// it gives up waiting after that long so the instance isn't kept frozen, leaving the sync to finish in the
+// background and the snapshot to possibly miss the writes still in flight.
+func (d *truenas) snapshotSync(path string) error {
+	if d.config["truenas.snapshot_sync_timeout"] == "" {
+		return linux.SyncFS(path)
+	}
+
+	timeout, err := time.ParseDuration(d.config["truenas.snapshot_sync_timeout"])
 	if err != nil {
 		return err
 	}
 
-	reverter.Add(func() { _ = d.DeleteVolumeSnapshot(vol, op) })
-
-	// For VM images, create a filesystem volume too.
-	if vol.IsVMBlock() {
-		fsVol := vol.NewVMBlockFilesystemVolume()
-		err := d.CreateVolumeSnapshot(fsVol, op)
-		if err != nil {
-			return err
-		}
+	errCh := make(chan error, 1)
+	go func() { errCh <- linux.SyncFS(path) }()
 
-		reverter.Add(func() { _ = d.DeleteVolumeSnapshot(fsVol, op) })
+	select {
+	case err := <-errCh:
+		return err
+	case <-time.After(timeout):
+		d.logger.Warn("Sync is taking too long, taking the snapshot anyway, it may not be consistent", logger.Ctx{"path": path, "timeout": timeout})
+		return nil
 	}
-
-	// All done.
-	reverter.Success()
-
-	return nil
 }
 
 // DeleteVolumeSnapshot removes a snapshot from the storage device.
 func (d *truenas) DeleteVolumeSnapshot(vol Volume, op *operations.Operation) error {
+	defer trackOperation("truenas", d.name, "DeleteVolumeSnapshot")()
+
+	err := d.checkWritable()
+	if err != nil {
+		return err
+	}
+
 	// Delete the snapshot, which will fail if there are clones.
 	dataset := d.dataset(vol, false)
 	errDelete := d.deleteSnapshot(dataset, true)
 
+	if errDelete != nil {
+		// Snapshots kept by truenas.restore_mode are already gone from the volume.
+		exists, err := d.datasetExists(dataset)
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			errDelete = nil
+		}
+	}
+
 	if errDelete != nil {
 		// Handle clones.
 		clones, err := d.getClones(dataset)
@@ -1569,7 +2181,7 @@ func (d *truenas) DeleteVolumeSnapshot(vol Volume, op *operations.Operation) err
 	}
 
 	// Delete the mountpoint.
-	err := os.Remove(vol.MountPath())
+	err = os.Remove(vol.MountPath())
 	if err != nil && !errors.Is(err, fs.ErrNotExist) {
 		return fmt.Errorf("Failed to remove '%s': %w", vol.MountPath(), err)
 	}
@@ -1596,7 +2208,13 @@ func (d *truenas) DeleteVolumeSnapshot(vol Volume, op *operations.Operation) err
 // MountVolumeSnapshot mounts a storage volume snapshot.
 //
 // The snapshot is cloned to a temporary dataset that will live for the duration of the mount.
+// Block snapshots are exposed through a read-only iSCSI share of the clone.
 func (d *truenas) MountVolumeSnapshot(snapVol Volume, op *operations.Operation) error {
+	err := d.checkWritable()
+	if err != nil {
+		return err
+	}
+
 	l := d.logger.AddContext(logger.Ctx{"volume": snapVol.Name()})
 	l.Debug("Mounting snapshot volume")
 
@@ -1614,7 +2232,19 @@ func (d *truenas) MountVolumeSnapshot(snapVol Volume, op *operations.Operation)
 	if snapVol.IsVMBlock() {
 		fsVol := snapVol.NewVMBlockFilesystemVolume()
 		l.Debug("Created a new FS volume", logger.Ctx{"fsVol": fsVol})
-		return d.MountVolumeSnapshot(fsVol, op)
+		err = d.MountVolumeSnapshot(fsVol, op)
+		if err != nil {
+			return err
+		}
+
+		reverter.Add(func() { _, _ = d.UnmountVolumeSnapshot(fsVol, op) })
+	}
+
+	// The temporary clone only needs to be setup by the first mount.
+	if snapVol.MountInUse() {
+		snapVol.MountRefCountIncrement()
+		reverter.Success()
+		return nil
 	}
 
 	srcSnapshot := d.dataset(snapVol, false)
@@ -1649,7 +2279,7 @@ func (d *truenas) MountVolumeSnapshot(snapVol Volume, op *operations.Operation)
 		mountPath := snapVol.MountPath()
 		l.Debug("Content type FS", logger.Ctx{"mountPath": mountPath})
 		if !linux.IsMountPoint(mountPath) {
-			err := snapVol.EnsureMountPath(false)
+			err := d.ensureMountDir(snapVol)
 			if err != nil {
 				return err
 			}
@@ -1663,7 +2293,7 @@ func (d *truenas) MountVolumeSnapshot(snapVol Volume, op *operations.Operation)
 				}
 			}
 
-			mountFlags, mountOptions := linux.ResolveMountOptions(strings.Split(snapVol.ConfigBlockMountOptions(), ","))
+			mountFlags, mountOptions := linux.ResolveMountOptions(strings.Split(d.blockMountOptions(snapVol), ","))
 
 			l.Debug("Regenerating filesystem UUID", logger.Ctx{"volDevPath": volDevPath, "fs": snapVolFS})
 			if renegerateFilesystemUUIDNeeded(snapVolFS) {
@@ -1710,23 +2340,27 @@ func (d *truenas) UnmountVolumeSnapshot(snapVol Volume, op *operations.Operation
 
 	defer unlock()
 
+	ourUnmount := false
+	mountPath := snapVol.MountPath()
+	refCount := snapVol.MountRefCountDecrement()
+
 	// For VMs, unmount the filesystem volume.
 	if snapVol.IsVMBlock() {
 		fsVol := snapVol.NewVMBlockFilesystemVolume()
-		return d.UnmountVolumeSnapshot(fsVol, op)
+		_, err = d.UnmountVolumeSnapshot(fsVol, op)
+		if err != nil && !errors.Is(err, ErrInUse) {
+			return false, err
+		}
 	}
 
-	ourUnmount := false
-	mountPath := snapVol.MountPath()
-	refCount := snapVol.MountRefCountDecrement()
+	// Keep the temporary clone around while other users remain.
+	if refCount > 0 {
+		d.logger.Debug("Skipping unmount as in use", logger.Ctx{"volName": snapVol.name, "refCount": refCount})
+		return false, ErrInUse
+	}
 
 	// Attempt to unmount the filesystem
 	if snapVol.contentType == ContentTypeFS && linux.IsMountPoint(mountPath) {
-		if refCount > 0 {
-			d.logger.Debug("Skipping unmount as in use", logger.Ctx{"volName": snapVol.name, "refCount": refCount})
-			return false, ErrInUse
-		}
-
 		err := linux.SyncFS(mountPath)
 		if err != nil {
 			return false, fmt.Errorf("Failed syncing filesystem %q: %w", mountPath, err)
@@ -1773,7 +2407,7 @@ func (d *truenas) VolumeSnapshots(vol Volume, op *operations.Operation) ([]strin
 	// Filter only the snapshots.
 	snapshots := []string{}
 	for _, entry := range entries {
-		after, ok := strings.CutPrefix(entry, "@snapshot-")
+		after, ok := strings.CutPrefix(entry, "@"+d.snapshotPrefix())
 		if ok {
 			snapshots = append(snapshots, after)
 		}
@@ -1784,6 +2418,13 @@ func (d *truenas) VolumeSnapshots(vol Volume, op *operations.Operation) ([]strin
 
 // RestoreVolume restores a volume from a snapshot.
 func (d *truenas) RestoreVolume(vol Volume, snapshotName string, op *operations.Operation) error {
+	defer trackOperation("truenas", d.name, "RestoreVolume")()
+
+	err := d.checkWritable()
+	if err != nil {
+		return err
+	}
+
 	return d.restoreVolume(vol, snapshotName, false, op)
 }
 
@@ -1799,7 +2440,7 @@ func (d *truenas) restoreVolume(vol Volume, snapshotName string, isMigration boo
 	idx := -1
 	snapshots := []string{}
 	for i, entry := range entries {
-		if entry == fmt.Sprintf("@snapshot-%s", snapshotName) {
+		if entry == fmt.Sprintf("@%s%s", d.snapshotPrefix(), snapshotName) {
 			// Located the current snapshot.
 			idx = i
 			continue
@@ -1808,7 +2449,7 @@ func (d *truenas) restoreVolume(vol Volume, snapshotName string, isMigration boo
 			continue
 		}
 
-		after, ok := strings.CutPrefix(entry, "@snapshot-")
+		after, ok := strings.CutPrefix(entry, "@"+d.snapshotPrefix())
 		if ok {
 			// Located a normal snapshot following ours.
 			snapshots = append(snapshots, after)
@@ -1823,8 +2464,29 @@ func (d *truenas) restoreVolume(vol Volume, snapshotName string, isMigration boo
 
 	// Check if snapshot removal is allowed.
 	if len(snapshots) > 0 {
-		if util.IsFalseOrEmpty(vol.ExpandedConfig("truenas.remove_snapshots")) {
-			return fmt.Errorf("Snapshot %q cannot be restored due to subsequent snapshot(s). Set truenas.remove_snapshots to override", snapshotName)
+		if !isMigration && vol.ExpandedConfig("truenas.restore_mode") == "copy" {
+			// Keep the subsequent snapshots on the TrueNAS host before they get removed from Incus.
+			reverter := revert.New()
+			defer reverter.Fail()
+
+			cleanup, err := d.restoreVolumeAsCopy(vol, entries[:idx+1])
+			if err != nil {
+				return err
+			}
+
+			reverter.Add(cleanup)
+
+			// For VMs, swap the filesystem dataset too, putting the block dataset back if that fails.
+			if vol.IsVMBlock() {
+				_, err := d.restoreVolumeAsCopy(vol.NewVMBlockFilesystemVolume(), entries[:idx+1])
+				if err != nil {
+					return err
+				}
+			}
+
+			reverter.Success()
+		} else if util.IsFalseOrEmpty(vol.ExpandedConfig("truenas.remove_snapshots")) {
+			return fmt.Errorf("Snapshot %q cannot be restored due to subsequent snapshot(s). Set truenas.remove_snapshots or truenas.restore_mode to override", snapshotName)
 		}
 
 		// Setup custom error to tell the backend what to delete.
@@ -1844,7 +2506,7 @@ func (d *truenas) restoreVolume(vol Volume, snapshotName string, isMigration boo
 
 	toRollback := make([]string, 0)
 	for _, dataset := range datasets {
-		if !strings.HasSuffix(dataset, fmt.Sprintf("@snapshot-%s", snapshotName)) {
+		if !strings.HasSuffix(dataset, fmt.Sprintf("@%s%s", d.snapshotPrefix(), snapshotName)) {
 			continue
 		}
 
@@ -1891,8 +2553,69 @@ func (d *truenas) restoreVolume(vol Volume, snapshotName string, isMigration boo
 	return nil
 }
 
+// restoreVolumeAsCopy moves the volume's dataset, along with all of its snapshots, to the deleted path and
+// replaces it with a copy containing only the supplied snapshots (the snapshot being restored and the ones
+// preceding it). The subsequent snapshots are kept on the TrueNAS host rather than being destroyed.
+// On success, it returns a cleanup function putting the original dataset back in place.
+func (d *truenas) restoreVolumeAsCopy(vol Volume, snapshots []string) (revert.Hook, error) {
+	dataset := d.dataset(vol, false)
+	keptDataset := d.retainedDatasetName(vol)
+
+	names := []string{}
+	for _, snapshot := range snapshots {
+		name, ok := strings.CutPrefix(snapshot, "@")
+		if ok && strings.HasPrefix(name, d.snapshotPrefix()) {
+			names = append(names, regexp.QuoteMeta(name))
+		}
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	_ = d.deleteIscsiShare(dataset)
+
+	err := d.retainDeletedDataset(dataset, keptDataset)
+	if err != nil {
+		return nil, err
+	}
+
+	reverter.Add(func() {
+		_ = d.deleteDatasetRecursive(dataset)
+		_ = d.renameDataset(keptDataset, dataset, false)
+		_ = d.createIscsiShare(dataset, false)
+	})
+
+	args := []string{"replication", "start", "--recursive", "--readonly-policy=ignore", "--name-regex", fmt.Sprintf("(%s)", strings.Join(names, "|")), keptDataset, dataset}
+	_, err = d.runTool(args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to replicate dataset: %w", err)
+	}
+
+	err = d.setDatasetProperties(dataset, d.creationMetadataOptions(vol)...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.createIscsiShare(dataset, false)
+	if err != nil {
+		return nil, err
+	}
+
+	d.logger.Info("Kept volume with its subsequent snapshots", logger.Ctx{"volume": vol.name, "dataset": keptDataset})
+
+	cleanup := reverter.Clone().Fail
+	reverter.Success()
+
+	return cleanup, nil
+}
+
 // RenameVolumeSnapshot renames a volume snapshot.
 func (d *truenas) RenameVolumeSnapshot(vol Volume, newSnapshotName string, op *operations.Operation) error {
+	err := d.checkWritable()
+	if err != nil {
+		return err
+	}
+
 	parentName, _, _ := api.GetParentAndSnapshotName(vol.name)
 	newVol := NewVolume(d, d.name, vol.volType, vol.contentType, fmt.Sprintf("%s/%s", parentName, newSnapshotName), vol.config, vol.poolConfig)
 
@@ -1901,7 +2624,7 @@ func (d *truenas) RenameVolumeSnapshot(vol Volume, newSnapshotName string, op *o
 	defer reverter.Fail()
 
 	// First rename the VFS paths.
-	err := genericVFSRenameVolumeSnapshot(d, vol, newSnapshotName, op)
+	err = genericVFSRenameVolumeSnapshot(d, vol, newSnapshotName, op)
 	if err != nil {
 		return err
 	}
@@ -20,6 +20,7 @@ type Info struct {
 	MountedRoot                  bool         // Whether the pool directory itself is a mount.
 	Deactivate                   bool         // Whether an unmount action is required prior to removing the pool.
 	ZeroUnpack                   bool         // Whether to write zeroes (no discard) during unpacking.
+	ContainerBlockVolumes        bool         // Whether custom block volumes can be passed to containers as block devices.
 }
 
 // VolumeFiller provides a struct for filling a volume.
@@ -20,6 +20,12 @@ var ErrInUse = errors.New("In use")
 // ErrSnapshotDoesNotMatchIncrementalSource in the "Snapshot does not match incremental source" error.
 var ErrSnapshotDoesNotMatchIncrementalSource = errors.New("Snapshot does not match incremental source")
 
+// ErrTrueNASUnreachable indicates that the TrueNAS host couldn't be reached.
+var ErrTrueNASUnreachable = errors.New("TrueNAS host unreachable")
+
+// ErrTrueNASAuth indicates that the TrueNAS host rejected the supplied credentials.
+var ErrTrueNASAuth = errors.New("TrueNAS authentication failed")
+
 // ErrDeleteSnapshots is a special error used to tell the backend to delete more recent snapshots.
 type ErrDeleteSnapshots struct {
 	Snapshots []string
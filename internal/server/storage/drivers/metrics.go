@@ -0,0 +1,88 @@
+package drivers
+
+import (
+	"maps"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lxc/incus/v6/internal/server/metrics"
+)
+
+// operationMetricBuckets are the upper bounds (in seconds) of the storage driver operation duration histogram.
+var operationMetricBuckets = []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 300}
+
+// operationMetricKey identifies the operations tracked by the storage driver metrics.
+type operationMetricKey struct {
+	driver    string
+	pool      string
+	operation string
+}
+
+// operationMetric holds the duration histogram of an operation.
+type operationMetric struct {
+	buckets []uint64 // Calls which took at most the matching operationMetricBuckets bound.
+	count   uint64
+	seconds float64
+}
+
+var operationMetrics = map[operationMetricKey]*operationMetric{}
+var operationMetricsMu sync.Mutex
+
+// trackOperation starts timing a storage driver operation. The returned function records it once done.
+func trackOperation(driverName string, poolName string, operation string) func() {
+	start := time.Now()
+
+	return func() {
+		duration := time.Since(start).Seconds()
+		key := operationMetricKey{driver: driverName, pool: poolName, operation: operation}
+
+		operationMetricsMu.Lock()
+		defer operationMetricsMu.Unlock()
+
+		metric, ok := operationMetrics[key]
+		if !ok {
+			metric = &operationMetric{buckets: make([]uint64, len(operationMetricBuckets))}
+			operationMetrics[key] = metric
+		}
+
+		for i, bound := range operationMetricBuckets {
+			if duration <= bound {
+				metric.buckets[i]++
+			}
+		}
+
+		metric.count++
+		metric.seconds += duration
+	}
+}
+
+// OperationMetrics returns the duration histograms of the tracked storage driver operations.
+func OperationMetrics() *metrics.MetricSet {
+	out := metrics.NewMetricSet(nil)
+
+	operationMetricsMu.Lock()
+	defer operationMetricsMu.Unlock()
+
+	for key, metric := range operationMetrics {
+		labels := map[string]string{"driver": key.driver, "pool": key.pool, "operation": key.operation}
+
+		bucketLabels := func(bound string) map[string]string {
+			l := maps.Clone(labels)
+			l["le"] = bound
+			return l
+		}
+
+		for i, bound := range operationMetricBuckets {
+			out.AddSamples(metrics.StorageDriverOperationSeconds, metrics.Sample{Value: float64(metric.buckets[i]), Labels: bucketLabels(strconv.FormatFloat(bound, 'g', -1, 64)), Suffix: "_bucket"})
+		}
+
+		out.AddSamples(metrics.StorageDriverOperationSeconds,
+			metrics.Sample{Value: float64(metric.count), Labels: bucketLabels("+Inf"), Suffix: "_bucket"},
+			metrics.Sample{Value: metric.seconds, Labels: labels, Suffix: "_sum"},
+			metrics.Sample{Value: float64(metric.count), Labels: maps.Clone(labels), Suffix: "_count"},
+		)
+	}
+
+	return out
+}
@@ -49,6 +49,8 @@ type Pool interface {
 	ToAPI() api.StoragePool
 
 	GetResources() (*api.ResourcesStoragePool, error)
+	Audit(op *operations.Operation) (map[string]string, error)
+	ReconcileVolumes(op *operations.Operation) ([]string, error)
 	IsUsed() (bool, error)
 	Delete(clientType request.ClientType, op *operations.Operation) error
 	Update(clientType request.ClientType, newDesc string, newConfig map[string]string, op *operations.Operation) error
@@ -146,6 +148,12 @@ type Pool interface {
 	BackupCustomVolume(projectName string, volName string, tarWriter *instancewriter.InstanceTarWriter, optimized bool, snapshots bool, op *operations.Operation) error
 	CreateCustomVolumeFromBackup(srcBackup backup.Info, srcData io.ReadSeeker, op *operations.Operation) error
 
+	// Storage volume disk export.
+	ExportVolumeDisk(projectName string, volType drivers.VolumeType, volName string, targetPath string, op *operations.Operation) error
+
+	// Storage volume driver info.
+	GetVolumeInfo(projectName string, volType drivers.VolumeType, volName string) (map[string]string, error)
+
 	// Storage volume recovery.
 	ListUnknownVolumes(op *operations.Operation) (map[string][]*backupConfig.Config, error)
 }
@@ -494,6 +494,39 @@ var APIExtensions = []string{
 	"disk_wwn",
 	"server_logging_webhook",
 	"storage_driver_truenas",
+	"storage_truenas_transfer_limit",
+	"network_load_balancer_backend_weight",
+	"storage_truenas_snapshot_prefix",
+	"storage_truenas_dry_run",
+	"instance_guestapi_endpoints",
+	"metrics_storage_driver_operations",
+	"storage_truenas_mount_options",
+	"storage_truenas_encryption",
+	"storage_truenas_force_unmount",
+	"storage_truenas_delete_retention",
+	"api_filtering_predicates",
+	"storage_truenas_max_concurrent_ops",
+	"storage_truenas_dataset_defaults",
+	"storage_truenas_restore_mode",
+	"image_cache",
+	"storage_truenas_user_properties",
+	"storage_truenas_image_restore",
+	"storage_volume_disk_export",
+	"devincus_cloud_init_status",
+	"devincus_network_config",
+	"storage_truenas_volume_parent",
+	"storage_pool_resources_driver_info",
+	"storage_truenas_independent",
+	"storage_truenas_snapshot_sync_timeout",
+	"storage_truenas_container_block_volumes",
+	"storage_volume_state_driver_info",
+	"storage_truenas_image_shares",
+	"storage_truenas_sync",
+	"storage_truenas_readonly",
+	"storage_truenas_compression",
+	"unix_device_mirror_path",
+	"storage_pool_audit",
+	"storage_pool_reconcile",
 }
 
 // APIExtensionsCount returns the number of available API extensions.
@@ -23,3 +23,12 @@ type DevIncusGet struct {
 	// Example: server01
 	Location string `json:"location" yaml:"location"`
 }
+
+// DevIncusCloudInitStatus represents the cloud-init status reported by the instance.
+//
+// API extension: devincus_cloud_init_status.
+type DevIncusCloudInitStatus struct {
+	// cloud-init status (running, done, error or disabled)
+	// Example: done
+	Status string `json:"status" yaml:"status"`
+}
@@ -38,6 +38,17 @@ type ImageExportPost struct {
 	Profiles []string `json:"profiles" yaml:"profiles"`
 }
 
+// ImageCachePost represents the fields required to cache an image in a storage pool
+//
+// swagger:model
+//
+// API extension: image_cache.
+type ImageCachePost struct {
+	// Storage pool to cache the image in
+	// Example: local
+	Pool string `json:"pool" yaml:"pool"`
+}
+
 // ImagesPost represents the fields available for a new image
 //
 // swagger:model
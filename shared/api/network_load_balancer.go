@@ -26,6 +26,12 @@ type NetworkLoadBalancerBackend struct {
 	// TargetAddress to forward ListenPorts to
 	// Example: 198.51.100.2
 	TargetAddress string `json:"target_address" yaml:"target_address"`
+
+	// Weight of the backend relative to the other backends (0 for the default weight)
+	// Example: 10
+	//
+	// API extension: network_load_balancer_backend_weight
+	Weight int `json:"weight,omitempty" yaml:"weight,omitempty"`
 }
 
 // Normalise normalises the fields in the load balancer backend so that they are comparable with ones stored.
@@ -801,6 +801,12 @@ type ResourcesStoragePool struct {
 
 	// Disk inode usage
 	Inodes ResourcesStoragePoolInodes `json:"inodes,omitempty" yaml:"inodes,omitempty"`
+
+	// Driver specific details (tool version, available features, ...)
+	// Example: {"version": "0.7.4", "iscsi": "true"}
+	//
+	// API extension: storage_pool_resources_driver_info
+	DriverInfo map[string]string `json:"driver_info,omitempty" yaml:"driver_info,omitempty"`
 }
 
 // ResourcesStoragePoolSpace represents the space available to a given storage pool
@@ -8,6 +8,12 @@ package api
 type StorageVolumeState struct {
 	// Volume usage
 	Usage *StorageVolumeStateUsage `json:"usage" yaml:"usage"`
+
+	// Driver specific details (clone origin, ...)
+	// Example: {"clone": "true", "origin": "tank/incus/images/0123456789abcdef@readonly"}
+	//
+	// API extension: storage_volume_state_driver_info
+	DriverInfo map[string]string `json:"driver_info,omitempty" yaml:"driver_info,omitempty"`
 }
 
 // StorageVolumeStateUsage represents the disk usage of a volume